@@ -26,7 +26,9 @@ func generate() *pluginpb.CodeGeneratorResponse {
 		}
 	}
 
-	return protoc.ApplyPluginFunction(resource.Generate, req)
+	params := &resource.Params{}
+
+	return protoc.ApplyPluginFunction(params.Generate, params.Set, req)
 }
 
 // writeResponse marshall response and write it to stdout
@@ -55,7 +57,7 @@ func parseProtocRequest() (*pluginpb.CodeGeneratorRequest, error) {
 	req := &pluginpb.CodeGeneratorRequest{}
 	err = proto.Unmarshal(data, req)
 	if err != nil {
-		return nil, fmt.Errorf("unable to unmarshall codegeneration request : %w")
+		return nil, fmt.Errorf("unable to unmarshall codegeneration request : %w", err)
 	}
 
 	return req, nil
@@ -0,0 +1,69 @@
+package tests
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/examples/protos"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/runtime/serializer/json"
+	"k8s.io/apimachinery/pkg/runtime/serializer/versioning"
+	"testing"
+)
+
+func TestObjectKindThroughVersioningCodec(t *testing.T) {
+	gvk := schema.GroupVersionKind{Group: "test.api.nrm.netcracker.com", Version: "hub", Kind: "ABitOfScalars"}
+
+	scheme := runtime.NewScheme()
+	scheme.AddKnownTypes(gvk.GroupVersion(), &protos.ABitOfScalars{})
+
+	serializer := json.NewSerializerWithOptions(json.DefaultMetaFactory, scheme, scheme, json.SerializerOptions{})
+	codec := versioning.NewDefaultingCodecForScheme(scheme, serializer, serializer, gvk.GroupVersion(), gvk.GroupVersion())
+
+	original := &protos.ABitOfScalars{StringType: "the answer", Int32Type: 42}
+
+	data, err := runtime.Encode(codec, original)
+	assert.NoError(t, err)
+	// codec sets GVK before encoding and restores it afterwards, static GVK must survive both
+	assert.Equal(t, gvk, original.GetObjectKind().GroupVersionKind())
+
+	t.Run("decode", func(t *testing.T) {
+		decoded, gotGvk, err := codec.Decode(data, &gvk, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, gvk, *gotGvk)
+		assert.Equal(t, gvk, decoded.GetObjectKind().GroupVersionKind())
+		assert.True(t, proto.Equal(original, decoded.(*protos.ABitOfScalars)))
+	})
+
+	t.Run("decode into", func(t *testing.T) {
+		into := &protos.ABitOfScalars{}
+		decoded, gotGvk, err := codec.Decode(data, nil, into)
+		assert.NoError(t, err)
+		assert.Equal(t, gvk, *gotGvk)
+		assert.Equal(t, gvk, decoded.GetObjectKind().GroupVersionKind())
+		assert.True(t, proto.Equal(original, decoded.(*protos.ABitOfScalars)))
+	})
+
+	t.Run("scheme", func(t *testing.T) {
+		obj, err := scheme.New(gvk)
+		assert.NoError(t, err)
+		obj.GetObjectKind().SetGroupVersionKind(schema.GroupVersionKind{})
+		assert.Equal(t, gvk, obj.GetObjectKind().GroupVersionKind())
+
+		kinds, _, err := scheme.ObjectKinds(obj)
+		assert.NoError(t, err)
+		assert.Equal(t, []schema.GroupVersionKind{gvk}, kinds)
+	})
+}
+
+func TestGetObjectKindDoesNotAllocate(t *testing.T) {
+	original := &protos.ABitOfScalars{}
+	allocs := testing.AllocsPerRun(100, func() {
+		kind := original.GetObjectKind()
+		kind.SetGroupVersionKind(schema.GroupVersionKind{})
+		_ = kind.GroupVersionKind()
+	})
+	assert.Equal(t, float64(0), allocs)
+	// all objects of the same type share ObjectKind
+	assert.Same(t, original.GetObjectKind(), (&protos.ABitOfScalars{}).GetObjectKind())
+}
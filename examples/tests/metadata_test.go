@@ -0,0 +1,107 @@
+package tests
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/examples/protos"
+	resourcemetav1 "github.com/dgodyna/protoc-gen-resource/protoc_gen_resource/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"testing"
+	"time"
+)
+
+func TestMetadataAccessors(t *testing.T) {
+	resource := &protos.ABitOfMetadata{}
+	var obj metav1.Object = resource
+
+	// getters of empty metadata return zero values
+	assert.Equal(t, "", obj.GetName())
+	assert.True(t, obj.GetCreationTimestamp().Time.IsZero())
+	assert.Nil(t, obj.GetDeletionTimestamp())
+	assert.Nil(t, obj.GetDeletionGracePeriodSeconds())
+
+	created := metav1.NewTime(time.Date(2021, 11, 1, 10, 0, 0, 0, time.UTC))
+	gracePeriod := int64(30)
+
+	obj.SetName("widget")
+	obj.SetNamespace("default")
+	obj.SetUID(types.UID("6d9c7e1c-1a8f-4c0a-9d0e-0f1f3d6e3a6b"))
+	obj.SetResourceVersion("42")
+	obj.SetGeneration(3)
+	obj.SetCreationTimestamp(created)
+	obj.SetDeletionTimestamp(&created)
+	obj.SetDeletionGracePeriodSeconds(&gracePeriod)
+	obj.SetLabels(map[string]string{"app": "widget"})
+	obj.SetAnnotations(map[string]string{"note": "example"})
+	obj.SetFinalizers([]string{"example.com/finalizer"})
+
+	assert.Equal(t, "widget", resource.Metadata.Name)
+	assert.Equal(t, "widget", obj.GetName())
+	assert.Equal(t, "default", obj.GetNamespace())
+	assert.Equal(t, types.UID("6d9c7e1c-1a8f-4c0a-9d0e-0f1f3d6e3a6b"), obj.GetUID())
+	assert.Equal(t, "42", obj.GetResourceVersion())
+	assert.Equal(t, int64(3), obj.GetGeneration())
+	creationTimestamp := obj.GetCreationTimestamp()
+	assert.True(t, created.Equal(&creationTimestamp))
+	assert.True(t, created.Equal(obj.GetDeletionTimestamp()))
+	assert.Equal(t, &gracePeriod, obj.GetDeletionGracePeriodSeconds())
+	assert.Equal(t, map[string]string{"app": "widget"}, obj.GetLabels())
+	assert.Equal(t, map[string]string{"note": "example"}, obj.GetAnnotations())
+	assert.Equal(t, []string{"example.com/finalizer"}, obj.GetFinalizers())
+
+	// fields absent in metadata message are ignored
+	obj.SetGenerateName("widget-")
+	assert.Equal(t, "", obj.GetGenerateName())
+	obj.SetOwnerReferences([]metav1.OwnerReference{{Name: "owner"}})
+	assert.Nil(t, obj.GetOwnerReferences())
+
+	obj.SetDeletionTimestamp(nil)
+	assert.Nil(t, resource.Metadata.DeletionTimestamp)
+
+	// GVK accessor is renamed by plugin parameter to don't clash with metav1.Object
+	assert.Equal(t, "hub", resource.GetAPIVersion())
+
+	accessor, err := meta.Accessor(resource)
+	assert.NoError(t, err)
+	assert.Equal(t, "widget", accessor.GetName())
+}
+
+func TestShippedMetadata(t *testing.T) {
+	controller := true
+	original := metav1.ObjectMeta{
+		Name:              "widget",
+		Namespace:         "default",
+		CreationTimestamp: metav1.NewTime(time.Unix(1635760800, 0)),
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "example.com/v1",
+			Kind:       "Gadget",
+			Name:       "gadget",
+			Controller: &controller,
+		}},
+	}
+
+	resource := &protos.ABitOfShippedMetadata{
+		Metadata: resourcemetav1.ObjectMetaFromMetav1(original),
+		Conditions: resourcemetav1.ConditionsFromMetav1([]metav1.Condition{{
+			Type:   "Ready",
+			Status: metav1.ConditionTrue,
+		}}),
+	}
+
+	var obj metav1.Object = resource
+	assert.Equal(t, "widget", obj.GetName())
+	assert.Equal(t, original.CreationTimestamp.Unix(), obj.GetCreationTimestamp().Unix())
+	assert.Equal(t, original.OwnerReferences, obj.GetOwnerReferences())
+	assert.Equal(t, &original.OwnerReferences[0], metav1.GetControllerOf(obj))
+
+	deleted := metav1.NewTime(time.Unix(1635764400, 0))
+	obj.SetDeletionTimestamp(&deleted)
+	assert.Equal(t, int64(1635764400), resource.Metadata.DeletionTimestamp.Seconds)
+
+	doppelganger := resource.DeepCopy()
+	assert.True(t, proto.Equal(resource, doppelganger))
+	assert.False(t, resource.Metadata == doppelganger.Metadata)
+	assert.False(t, resource.Conditions[0] == doppelganger.Conditions[0])
+}
@@ -0,0 +1,80 @@
+package tests
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/examples/protos"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/proto"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"testing"
+)
+
+func TestRegisterHelpers(t *testing.T) {
+	assert.Equal(t, "test.api.nrm.netcracker.com", protos.GroupName)
+	assert.Equal(t, schema.GroupVersion{Group: "test.api.nrm.netcracker.com", Version: "hub"}, protos.SchemeGroupVersion)
+	assert.Equal(t, schema.GroupKind{Group: "test.api.nrm.netcracker.com", Kind: "ABitOfScalars"}, protos.Kind("ABitOfScalars"))
+	assert.Equal(t, schema.GroupResource{Group: "test.api.nrm.netcracker.com", Resource: "abitofscalars"}, protos.Resource("abitofscalars"))
+
+	resource := &protos.ABitOfScalars{}
+	assert.Equal(t, protos.SchemeGroupVersion.WithKind("ABitOfScalars"), protos.ABitOfScalarsGroupVersionKind)
+	assert.Equal(t, protos.ABitOfScalarsGroupVersionKind, resource.GroupVersionKind())
+	assert.Equal(t, resource.GetObjectKind().GroupVersionKind(), resource.GroupVersionKind())
+}
+
+func TestAddToScheme(t *testing.T) {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, protos.AddToScheme(scheme))
+
+	obj, err := scheme.New(protos.ABitOfScalarsGroupVersionKind)
+	assert.NoError(t, err)
+	assert.IsType(t, &protos.ABitOfScalars{}, obj)
+
+	obj, err = scheme.New(protos.ABitOfModesGroupVersionKind)
+	assert.NoError(t, err)
+	assert.IsType(t, &protos.ABitOfModes{}, obj)
+
+	gvks, _, err := scheme.ObjectKinds(&protos.ABitOfMessages{})
+	assert.NoError(t, err)
+	assert.Equal(t, []schema.GroupVersionKind{protos.ABitOfMessagesGroupVersionKind}, gvks)
+
+	// metav1 types are registered for the group version
+	_, err = scheme.New(protos.SchemeGroupVersion.WithKind("WatchEvent"))
+	assert.NoError(t, err)
+
+	// deepcopy only messages are not registered
+	assert.False(t, scheme.Recognizes(protos.SchemeGroupVersion.WithKind("DeepCopyOnly")))
+}
+
+func TestList(t *testing.T) {
+	original := &protos.ABitOfScalarsList{
+		ListMeta: metav1.ListMeta{ResourceVersion: "42", Continue: "token"},
+		Items: []*protos.ABitOfScalars{
+			{StringType: "first"},
+			nil,
+			{StringType: "second"},
+		},
+	}
+
+	doppelganger := original.DeepCopy()
+	assert.Equal(t, original.ListMeta, doppelganger.ListMeta)
+	assert.Len(t, doppelganger.Items, 3)
+	for i := range original.Items {
+		assert.True(t, proto.Equal(original.Items[i], doppelganger.Items[i]))
+		if original.Items[i] != nil {
+			assert.False(t, original.Items[i] == doppelganger.Items[i], "items must be copied")
+		}
+	}
+
+	var obj runtime.Object = original
+	assert.Equal(t, protos.ABitOfScalarsListGroupVersionKind, obj.GetObjectKind().GroupVersionKind())
+	assert.IsType(t, &protos.ABitOfScalarsList{}, obj.DeepCopyObject())
+
+	scheme := runtime.NewScheme()
+	assert.NoError(t, protos.AddToScheme(scheme))
+	list, err := scheme.New(protos.ABitOfScalarsListGroupVersionKind)
+	assert.NoError(t, err)
+	assert.IsType(t, &protos.ABitOfScalarsList{}, list)
+	assert.True(t, meta.IsListType(list))
+}
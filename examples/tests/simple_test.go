@@ -3,10 +3,22 @@ package tests
 import (
 	"github.com/dgodyna/protoc-gen-resource/examples/protos"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/anypb"
+	"google.golang.org/protobuf/types/known/apipb"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/typepb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 	_ "k8s.io/apimachinery/pkg/apis/meta/v1"
-	_ "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime"
 	"testing"
+	"time"
 )
 
 func TestSimpleDeepCopy(t *testing.T) {
@@ -127,6 +139,53 @@ func TestOptionalsDeepCopy(t *testing.T) {
 	assert.Equal(t, "the answer", string(doppelganger.BytesType))
 }
 
+func TestPartialOptionalsDeepCopy(t *testing.T) {
+	var i64 = int64(42)
+	var s = "the answer"
+	var e = protos.ABitOfOptionals_ENGINE_TYPE_GAS
+
+	original := &protos.ABitOfOptionals{
+		Int64Type:   &i64,
+		StringType:  &s,
+		BytesType:   []byte{},
+		EnumType:    &e,
+		MessageType: &protos.ABitOfOptionals_OptionalSub{I1: 42},
+	}
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	assert.True(t, proto.Equal(original, doppelganger))
+
+	// unset fields stay unset
+	assert.Nil(t, doppelganger.DoubleType)
+	assert.Nil(t, doppelganger.Int32Type)
+	assert.Nil(t, doppelganger.BoolType)
+	assert.False(t, doppelganger.ProtoReflect().Has(doppelganger.ProtoReflect().Descriptor().Fields().ByName("double_type")))
+
+	// set fields got fresh pointers
+	assert.NotSame(t, original.Int64Type, doppelganger.Int64Type)
+	assert.NotSame(t, original.StringType, doppelganger.StringType)
+	assert.NotSame(t, original.EnumType, doppelganger.EnumType)
+	assert.NotSame(t, original.MessageType, doppelganger.MessageType)
+
+	// set but empty bytes stay set
+	assert.NotNil(t, doppelganger.BytesType)
+	assert.Empty(t, doppelganger.BytesType)
+
+	i64++
+	s = "changed"
+	e = protos.ABitOfOptionals_ENGINE_TYPE_DIESEL
+	original.MessageType.I1 = 21
+
+	assert.Equal(t, int64(42), *doppelganger.Int64Type)
+	assert.Equal(t, "the answer", *doppelganger.StringType)
+	assert.Equal(t, protos.ABitOfOptionals_ENGINE_TYPE_GAS, *doppelganger.EnumType)
+	assert.Equal(t, int64(42), doppelganger.MessageType.I1)
+
+	// and nothing set at all
+	assert.True(t, proto.Equal(&protos.ABitOfOptionals{}, (&protos.ABitOfOptionals{}).DeepCopy()))
+}
+
 func TestEnumsDeepCopy(t *testing.T) {
 
 	original := &protos.ABitOfEnums{
@@ -269,3 +328,384 @@ func TestRepeatedMessagesDeepCopy(t *testing.T) {
 	}, doppelganger.First)
 
 }
+
+func TestMapsDeepCopy(t *testing.T) {
+	original := &protos.ABitOfMaps{
+		StringType: map[string]string{"a": "bit", "of": "everything"},
+		Int64Type:  map[int32]int64{1: 42, 2: 21},
+		DoubleType: map[bool]float64{true: 42},
+		BytesType:  map[string][]byte{"answer": []byte("42"), "nil": nil},
+		EnumType:   map[string]protos.ABitOfMaps_EngineType{"gas": protos.ABitOfMaps_ENGINE_TYPE_GAS},
+		MessageType: map[string]*protos.ABitOfMaps_MapSub{
+			"first": {I1: 42, I2: 42},
+			"nil":   nil,
+		},
+	}
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	assert.Equal(t, original, doppelganger, protocmp.Transform())
+
+	// now change the original in place
+	original.StringType["a"] = "changed"
+	original.Int64Type[1] = 0
+	original.DoubleType[false] = 21
+	original.BytesType["answer"][0] = '0'
+	original.EnumType["gas"] = protos.ABitOfMaps_ENGINE_TYPE_DIESEL
+	original.MessageType["first"].I1 = 0
+
+	// and check that doppelganger was unchanged
+	assert.Equal(t, map[string]string{"a": "bit", "of": "everything"}, doppelganger.StringType)
+	assert.Equal(t, map[int32]int64{1: 42, 2: 21}, doppelganger.Int64Type)
+	assert.Equal(t, map[bool]float64{true: 42}, doppelganger.DoubleType)
+	assert.Equal(t, map[string][]byte{"answer": []byte("42"), "nil": nil}, doppelganger.BytesType)
+	assert.Equal(t, map[string]protos.ABitOfMaps_EngineType{"gas": protos.ABitOfMaps_ENGINE_TYPE_GAS}, doppelganger.EnumType)
+	assert.Equal(t, int64(42), doppelganger.MessageType["first"].I1)
+	assert.Nil(t, doppelganger.MessageType["nil"])
+}
+
+func TestMapsNilAndEmptyDeepCopy(t *testing.T) {
+	original := &protos.ABitOfMaps{
+		StringType:  map[string]string{},
+		MessageType: map[string]*protos.ABitOfMaps_MapSub{},
+	}
+
+	doppelganger := original.DeepCopy()
+
+	assert.NotNil(t, doppelganger.StringType)
+	assert.Empty(t, doppelganger.StringType)
+	assert.NotNil(t, doppelganger.MessageType)
+	assert.Empty(t, doppelganger.MessageType)
+	assert.Nil(t, doppelganger.Int64Type)
+	assert.Nil(t, doppelganger.BytesType)
+	assert.Nil(t, doppelganger.EnumType)
+}
+
+func TestOneOfsDeepCopy(t *testing.T) {
+	optional := "optional"
+	original := &protos.ABitOfOneOfs{
+		Name: "name",
+		Kind: &protos.ABitOfOneOfs_MessageType{
+			MessageType: &protos.ABitOfOneOfs_OneOfSub{I1: 42, I2: 42},
+		},
+		AnotherKind:  &protos.ABitOfOneOfs_DoubleType{DoubleType: 42},
+		OptionalType: &optional,
+	}
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	assert.Equal(t, original, doppelganger, protocmp.Transform())
+
+	// now change the original in place
+	original.GetMessageType().I1 = 21
+	original.AnotherKind.(*protos.ABitOfOneOfs_DoubleType).DoubleType = 21
+
+	// and check that doppelganger was unchanged
+	assert.Equal(t, int64(42), doppelganger.GetMessageType().I1)
+	assert.Equal(t, float64(42), doppelganger.GetDoubleType())
+
+	// switch the active cases
+	original.Kind = &protos.ABitOfOneOfs_StringType{StringType: "string"}
+	original.AnotherKind = nil
+	doppelganger = original.DeepCopy()
+	assert.Equal(t, original, doppelganger, protocmp.Transform())
+	assert.Equal(t, "string", doppelganger.GetStringType())
+	assert.Nil(t, doppelganger.AnotherKind)
+
+	original.Kind = &protos.ABitOfOneOfs_EnumType{EnumType: protos.ABitOfOneOfs_ENGINE_TYPE_GAS}
+	doppelganger = original.DeepCopy()
+	assert.Equal(t, protos.ABitOfOneOfs_ENGINE_TYPE_GAS, doppelganger.GetEnumType())
+
+	original.Kind = &protos.ABitOfOneOfs_BytesType{BytesType: []byte("bytes")}
+	doppelganger = original.DeepCopy()
+	assert.Equal(t, []byte("bytes"), doppelganger.GetBytesType())
+}
+
+func TestWellKnownsDeepCopy(t *testing.T) {
+	st, err := structpb.NewStruct(map[string]interface{}{
+		"nested": map[string]interface{}{"answer": 42},
+	})
+	assert.NoError(t, err)
+	anyMsg, err := anypb.New(&protos.ABitOfScalars{StringType: "any"})
+	assert.NoError(t, err)
+
+	original := &protos.ABitOfWellKnowns{
+		TimestampType:         timestamppb.New(time.Unix(42, 42)),
+		DurationType:          durationpb.New(42 * time.Second),
+		StructType:            st,
+		ValueType:             structpb.NewStringValue("value"),
+		ListValueType:         &structpb.ListValue{Values: []*structpb.Value{structpb.NewNumberValue(42)}},
+		AnyType:               anyMsg,
+		FieldMaskType:         &fieldmaskpb.FieldMask{Paths: []string{"a", "b"}},
+		EmptyType:             &emptypb.Empty{},
+		DoubleValueType:       wrapperspb.Double(42),
+		FloatValueType:        wrapperspb.Float(42),
+		Int64ValueType:        wrapperspb.Int64(42),
+		Uint64ValueType:       wrapperspb.UInt64(42),
+		Int32ValueType:        wrapperspb.Int32(42),
+		Uint32ValueType:       wrapperspb.UInt32(42),
+		BoolValueType:         wrapperspb.Bool(true),
+		StringValueType:       wrapperspb.String("42"),
+		BytesValueType:        wrapperspb.Bytes([]byte("42")),
+		RepeatedTimestampType: []*timestamppb.Timestamp{timestamppb.New(time.Unix(42, 0)), nil},
+		RepeatedStructType:    []*structpb.Struct{st},
+		RepeatedAnyType:       []*anypb.Any{anyMsg},
+		MapDurationType:       map[string]*durationpb.Duration{"answer": durationpb.New(42)},
+		MapValueType:          map[string]*structpb.Value{"answer": structpb.NewNumberValue(42)},
+		MapStringValueType:    map[string]*wrapperspb.StringValue{"answer": wrapperspb.String("42")},
+		Kind:                  &protos.ABitOfWellKnowns_OneofStructType{OneofStructType: st},
+	}
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	assert.Equal(t, original, doppelganger, protocmp.Transform())
+
+	// now change the original in place
+	original.TimestampType.Seconds = 0
+	original.DurationType.Nanos = 1
+	st.Fields["nested"].GetStructValue().Fields["answer"] = structpb.NewNumberValue(21)
+	original.AnyType.Value[0] = 0
+	original.FieldMaskType.Paths[0] = "c"
+	original.BytesValueType.Value[0] = '0'
+	original.RepeatedTimestampType[0].Seconds = 0
+	original.MapDurationType["answer"].Seconds = 1
+	original.MapValueType["answer"].Kind = &structpb.Value_NumberValue{NumberValue: 21}
+	original.MapStringValueType["answer"].Value = "21"
+
+	// and check that doppelganger was unchanged
+	assert.Equal(t, int64(42), doppelganger.TimestampType.Seconds)
+	assert.Equal(t, int32(0), doppelganger.DurationType.Nanos)
+	assert.Equal(t, float64(42), doppelganger.StructType.Fields["nested"].GetStructValue().Fields["answer"].GetNumberValue())
+	assert.Equal(t, float64(42), doppelganger.RepeatedStructType[0].Fields["nested"].GetStructValue().Fields["answer"].GetNumberValue())
+	assert.Equal(t, float64(42), doppelganger.GetOneofStructType().Fields["nested"].GetStructValue().Fields["answer"].GetNumberValue())
+	assert.Equal(t, anyMsg.TypeUrl, doppelganger.AnyType.TypeUrl)
+	assert.NotEqual(t, byte(0), doppelganger.AnyType.Value[0])
+	assert.Equal(t, []string{"a", "b"}, doppelganger.FieldMaskType.Paths)
+	assert.Equal(t, []byte("42"), doppelganger.BytesValueType.Value)
+	assert.Equal(t, int64(42), doppelganger.RepeatedTimestampType[0].Seconds)
+	assert.Nil(t, doppelganger.RepeatedTimestampType[1])
+	assert.Equal(t, int64(0), doppelganger.MapDurationType["answer"].Seconds)
+	assert.Equal(t, float64(42), doppelganger.MapValueType["answer"].GetNumberValue())
+	assert.Equal(t, "42", doppelganger.MapStringValueType["answer"].Value)
+}
+
+func TestThirdPartiesDeepCopy(t *testing.T) {
+	original := &protos.ABitOfThirdParties{
+		ApiType: &apipb.Api{
+			Name:    "api",
+			Methods: []*apipb.Method{{Name: "method"}},
+		},
+		RepeatedType: []*typepb.Type{{Name: "type"}, nil},
+		MapType: map[string]*apipb.Api{
+			"api": {Name: "api"},
+			"nil": nil,
+		},
+	}
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	// proto.Clone initializes internal message state, so compare by proto.Equal
+	assert.True(t, proto.Equal(original, doppelganger))
+
+	// now change the original in place
+	original.ApiType.Methods[0].Name = "changed"
+	original.RepeatedType[0].Name = "changed"
+	original.MapType["api"].Name = "changed"
+
+	// and check that doppelganger was unchanged
+	assert.Equal(t, "method", doppelganger.ApiType.Methods[0].Name)
+	assert.Equal(t, "type", doppelganger.RepeatedType[0].Name)
+	assert.Nil(t, doppelganger.RepeatedType[1])
+	assert.Equal(t, "api", doppelganger.MapType["api"].Name)
+	assert.Nil(t, doppelganger.MapType["nil"])
+}
+
+func TestProto2DeepCopy(t *testing.T) {
+	original := &protos.ABitOfProto2{
+		RequiredType:     proto.String("required"),
+		StringType:       proto.String("string"),
+		BytesType:        []byte("bytes"),
+		EnumType:         protos.ABitOfProto2_ENGINE_TYPE_DIESEL.Enum(),
+		RepeatedType:     []int32{42, 21},
+		RepeatedEnumType: []protos.ABitOfProto2_EngineType{protos.ABitOfProto2_ENGINE_TYPE_GAS},
+		MessageType:      &protos.ABitOfProto2_Proto2Sub{I1: proto.Int64(42)},
+		Optionalgroup:    &protos.ABitOfProto2_OptionalGroup{I1: proto.Int64(42)},
+		Repeatedgroup:    []*protos.ABitOfProto2_RepeatedGroup{{S1: proto.String("group")}},
+	}
+	proto.SetExtension(original, protos.E_StringExtension, "extension")
+	proto.SetExtension(original, protos.E_MessageExtension, &protos.ABitOfProto2_Proto2Sub{I1: proto.Int64(42)})
+	proto.SetExtension(original, protos.E_RepeatedExtension, []int64{42, 21})
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	assert.True(t, proto.Equal(original, doppelganger))
+
+	// defaults are kept for unset fields
+	assert.Nil(t, doppelganger.Int64Type)
+	assert.Equal(t, int64(42), doppelganger.GetInt64Type())
+	assert.Equal(t, "string", doppelganger.GetStringType())
+
+	// extensions are copied
+	assert.Equal(t, "extension", proto.GetExtension(doppelganger, protos.E_StringExtension))
+	assert.Equal(t, []int64{42, 21}, proto.GetExtension(doppelganger, protos.E_RepeatedExtension))
+
+	// now change the original in place
+	*original.RequiredType = "changed"
+	original.Optionalgroup.I1 = proto.Int64(21)
+	original.Repeatedgroup[0].S1 = proto.String("changed")
+	proto.GetExtension(original, protos.E_MessageExtension).(*protos.ABitOfProto2_Proto2Sub).I1 = proto.Int64(21)
+	proto.SetExtension(original, protos.E_StringExtension, "changed")
+
+	// and check that doppelganger was unchanged
+	assert.Equal(t, "required", doppelganger.GetRequiredType())
+	assert.Equal(t, int64(42), doppelganger.Optionalgroup.GetI1())
+	assert.Equal(t, "group", doppelganger.Repeatedgroup[0].GetS1())
+	assert.Equal(t, int64(42), proto.GetExtension(doppelganger, protos.E_MessageExtension).(*protos.ABitOfProto2_Proto2Sub).GetI1())
+	assert.Equal(t, "extension", proto.GetExtension(doppelganger, protos.E_StringExtension))
+}
+
+func TestUnknownFieldsDeepCopy(t *testing.T) {
+	// message written by a newer schema version: known fields plus field 42 unknown for current schema
+	known, err := proto.Marshal(&protos.ABitOfMessages{
+		First: &protos.AnotherM{F1: "first"},
+	})
+	assert.NoError(t, err)
+	unknown := protowire.AppendTag(nil, 42, protowire.BytesType)
+	unknown = protowire.AppendString(unknown, "from the future")
+	// unknown field for nested message as well
+	nestedUnknown := protowire.AppendTag(nil, 42, protowire.VarintType)
+	nestedUnknown = protowire.AppendVarint(nestedUnknown, 42)
+	nested := protowire.AppendTag(nil, 2, protowire.BytesType)
+	nested = protowire.AppendBytes(nested, nestedUnknown)
+
+	data := append(append(known, unknown...), nested...)
+
+	original := &protos.ABitOfMessages{}
+	assert.NoError(t, proto.Unmarshal(data, original))
+	assert.NotEmpty(t, original.ProtoReflect().GetUnknown())
+	assert.NotEmpty(t, original.Second.ProtoReflect().GetUnknown())
+
+	doppelganger := original.DeepCopy()
+	assert.True(t, proto.Equal(original, doppelganger))
+	assert.Equal(t, original.ProtoReflect().GetUnknown(), doppelganger.ProtoReflect().GetUnknown())
+	assert.Equal(t, original.Second.ProtoReflect().GetUnknown(), doppelganger.Second.ProtoReflect().GetUnknown())
+
+	// round trip through marshalling keeps unknown fields
+	gotData, err := proto.MarshalOptions{Deterministic: true}.Marshal(doppelganger)
+	assert.NoError(t, err)
+	wantData, err := proto.MarshalOptions{Deterministic: true}.Marshal(original)
+	assert.NoError(t, err)
+	assert.Equal(t, wantData, gotData)
+
+	// unknown fields are not shared
+	original.ProtoReflect().GetUnknown()[0] = 0
+	assert.Equal(t, unknown, []byte(doppelganger.ProtoReflect().GetUnknown()))
+}
+
+func TestEditionsDeepCopy(t *testing.T) {
+	original := &protos.ABitOfEditions{
+		ExplicitType:           proto.String(""),
+		ImplicitType:           "implicit",
+		RequiredType:           proto.Int64(42),
+		ExplicitBytesType:      []byte{},
+		ImplicitBytesType:      []byte("implicit"),
+		ClosedEnumType:         protos.ClosedEnum_CLOSED_ENUM_SECOND.Enum(),
+		RepeatedClosedEnumType: []protos.ClosedEnum{protos.ClosedEnum_CLOSED_ENUM_FIRST},
+		DelimitedType:          &protos.EditionsSub{I1: proto.Int64(42)},
+		RepeatedDelimitedType:  []*protos.EditionsSub{{I1: proto.Int64(42)}},
+		ExpandedType:           []int32{42, 21},
+	}
+
+	// check deepcopy itself
+	doppelganger := original.DeepCopy()
+	assert.True(t, proto.Equal(original, doppelganger))
+
+	// explicit presence is kept: set to zero value stays set, unset stays unset
+	assert.NotNil(t, doppelganger.ExplicitType)
+	assert.NotNil(t, doppelganger.ExplicitBytesType)
+	assert.Nil(t, doppelganger.OpenEnumType)
+	assert.Nil(t, doppelganger.MessageType)
+
+	// now change the original in place
+	*original.RequiredType = 21
+	*original.ClosedEnumType = protos.ClosedEnum_CLOSED_ENUM_FIRST
+	original.DelimitedType.I1 = proto.Int64(21)
+	original.RepeatedDelimitedType[0].I1 = proto.Int64(21)
+
+	// and check that doppelganger was unchanged
+	assert.Equal(t, int64(42), doppelganger.GetRequiredType())
+	assert.Equal(t, protos.ClosedEnum_CLOSED_ENUM_SECOND, doppelganger.GetClosedEnumType())
+	assert.Equal(t, int64(42), doppelganger.DelimitedType.GetI1())
+	assert.Equal(t, int64(42), doppelganger.RepeatedDelimitedType[0].GetI1())
+}
+
+func TestBytesDeepCopy(t *testing.T) {
+	t.Run("singular", func(t *testing.T) {
+		original := &protos.ABitOfScalars{BytesType: []byte("bytes")}
+		doppelganger := original.DeepCopy()
+		doppelganger.BytesType[0] = 'B'
+		assert.Equal(t, []byte("bytes"), original.BytesType)
+
+		// empty stays empty, nil stays nil
+		assert.NotNil(t, (&protos.ABitOfScalars{BytesType: []byte{}}).DeepCopy().BytesType)
+		assert.Nil(t, (&protos.ABitOfScalars{}).DeepCopy().BytesType)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		original := &protos.ABitOfOptionals{BytesType: []byte("bytes")}
+		doppelganger := original.DeepCopy()
+		doppelganger.BytesType[0] = 'B'
+		assert.Equal(t, []byte("bytes"), original.BytesType)
+	})
+
+	t.Run("repeated", func(t *testing.T) {
+		original := &protos.ABitOfRepeatedScalars{BytesType: [][]byte{[]byte("bytes"), {}, nil}}
+		doppelganger := original.DeepCopy()
+		doppelganger.BytesType[0][0] = 'B'
+		assert.Equal(t, []byte("bytes"), original.BytesType[0])
+		assert.NotNil(t, doppelganger.BytesType[1])
+		assert.Nil(t, doppelganger.BytesType[2])
+	})
+
+	t.Run("map value", func(t *testing.T) {
+		original := &protos.ABitOfMaps{BytesType: map[string][]byte{"bytes": []byte("bytes"), "empty": {}, "nil": nil}}
+		doppelganger := original.DeepCopy()
+		doppelganger.BytesType["bytes"][0] = 'B'
+		assert.Equal(t, []byte("bytes"), original.BytesType["bytes"])
+		assert.NotNil(t, doppelganger.BytesType["empty"])
+		assert.Nil(t, doppelganger.BytesType["nil"])
+	})
+
+	t.Run("oneof case", func(t *testing.T) {
+		original := &protos.ABitOfOneOfs{Kind: &protos.ABitOfOneOfs_BytesType{BytesType: []byte("bytes")}}
+		doppelganger := original.DeepCopy()
+		doppelganger.GetBytesType()[0] = 'B'
+		assert.Equal(t, []byte("bytes"), original.GetBytesType())
+	})
+}
+
+func TestModes(t *testing.T) {
+	original := &protos.ABitOfModes{
+		DeepCopyOnly: &protos.DeepCopyOnly{Name: "deepcopy"},
+		Skipped:      &protos.Skipped{Name: "skipped"},
+	}
+
+	doppelganger := original.DeepCopy()
+	assert.True(t, proto.Equal(original, doppelganger))
+	assert.False(t, original.DeepCopyOnly == doppelganger.DeepCopyOnly)
+	assert.False(t, original.Skipped == doppelganger.Skipped)
+
+	var resource interface{} = original
+	_, isObject := resource.(runtime.Object)
+	assert.True(t, isObject, "resource must implement runtime.Object")
+
+	var deepCopyOnly interface{} = original.DeepCopyOnly
+	_, isObject = deepCopyOnly.(runtime.Object)
+	assert.False(t, isObject, "deepcopy only message must not implement runtime.Object")
+	_, hasDeepCopy := deepCopyOnly.(interface{ DeepCopy() *protos.DeepCopyOnly })
+	assert.True(t, hasDeepCopy, "deepcopy only message must have DeepCopy")
+
+	var skipped interface{} = original.Skipped
+	_, hasDeepCopy = skipped.(interface{ DeepCopy() *protos.Skipped })
+	assert.False(t, hasDeepCopy, "skipped message must not have DeepCopy")
+}
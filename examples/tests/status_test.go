@@ -0,0 +1,48 @@
+package tests
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/examples/protos"
+	resourcemetav1 "github.com/dgodyna/protoc-gen-resource/protoc_gen_resource/meta/v1"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSpecStatusAccessors(t *testing.T) {
+	resource := &protos.ABitOfStatus{Desired: &protos.ABitOfStatusSpec{Replicas: 3}}
+
+	assert.Same(t, resource.Desired, resource.GetSpec())
+	assert.Nil(t, resource.GetStatus())
+
+	status := &protos.ABitOfStatusStatus{ObservedGeneration: 2}
+	resource.SetStatus(status)
+	assert.Same(t, status, resource.GetStatus())
+
+	assert.Nil(t, (*protos.ABitOfStatus)(nil).GetSpec())
+}
+
+func TestCopyStatusFrom(t *testing.T) {
+	server := &protos.ABitOfStatus{
+		Desired: &protos.ABitOfStatusSpec{Replicas: 1},
+		Status: &protos.ABitOfStatusStatus{
+			ObservedGeneration: 5,
+			Conditions:         []*resourcemetav1.Condition{{Type: "Ready", Status: "True"}},
+		},
+	}
+	local := &protos.ABitOfStatus{Desired: &protos.ABitOfStatusSpec{Replicas: 3}}
+
+	local.CopyStatusFrom(server)
+
+	// status is copied deeply, spec is kept
+	assert.Equal(t, int32(3), local.GetSpec().GetReplicas())
+	assert.Equal(t, int64(5), local.GetStatus().GetObservedGeneration())
+	assert.NotSame(t, server.Status, local.Status)
+	local.Status.Conditions[0].Status = "False"
+	assert.Equal(t, "True", server.Status.Conditions[0].Status)
+
+	// status is reset if other has no status
+	local.CopyStatusFrom(&protos.ABitOfStatus{})
+	assert.Nil(t, local.GetStatus())
+	local.SetStatus(&protos.ABitOfStatusStatus{})
+	local.CopyStatusFrom(nil)
+	assert.Nil(t, local.GetStatus())
+}
@@ -0,0 +1,36 @@
+// Package objectkind contains schema.ObjectKind implementation used by generated GetObjectKind methods.
+package objectkind
+
+import (
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// Static is schema.ObjectKind of resource with group, version and kind defined by its protobuf message.
+//
+// Protobuf messages have no place to keep TypeMeta, so GVK can't be stored per object. Instead, Static always reports
+// the GVK of the resource and SetGroupVersionKind is a no-op: serializers and schemes may call it, but the GVK
+// of the object never changes. Static is shared by all objects of the same type and GroupVersionKind doesn't allocate.
+type Static struct {
+	gvk schema.GroupVersionKind
+}
+
+// NewStatic creates Static ObjectKind for provided group, version and kind.
+func NewStatic(group, version, kind string) *Static {
+	return &Static{
+		gvk: schema.GroupVersionKind{
+			Group:   group,
+			Version: version,
+			Kind:    kind,
+		},
+	}
+}
+
+// SetGroupVersionKind is ignored, GVK of resource is defined by its protobuf message.
+func (s *Static) SetGroupVersionKind(schema.GroupVersionKind) {}
+
+// GroupVersionKind returns GVK of the resource.
+func (s *Static) GroupVersionKind() schema.GroupVersionKind {
+	return s.gvk
+}
+
+var _ schema.ObjectKind = (*Static)(nil)
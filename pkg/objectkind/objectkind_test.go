@@ -0,0 +1,28 @@
+package objectkind
+
+import (
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"testing"
+)
+
+func TestStatic(t *testing.T) {
+	want := schema.GroupVersionKind{Group: "widgets.example.com", Version: "v1", Kind: "Widget"}
+	kind := NewStatic("widgets.example.com", "v1", "Widget")
+
+	assert.Equal(t, want, kind.GroupVersionKind())
+
+	// sets are ignored
+	kind.SetGroupVersionKind(schema.GroupVersionKind{Group: "other.example.com", Version: "v2", Kind: "Other"})
+	assert.Equal(t, want, kind.GroupVersionKind())
+	kind.SetGroupVersionKind(schema.GroupVersionKind{})
+	assert.Equal(t, want, kind.GroupVersionKind())
+
+	// shared instance must not allocate
+	var objectKind schema.ObjectKind = kind
+	allocs := testing.AllocsPerRun(100, func() {
+		objectKind.SetGroupVersionKind(schema.GroupVersionKind{})
+		_ = objectKind.GroupVersionKind()
+	})
+	assert.Equal(t, float64(0), allocs)
+}
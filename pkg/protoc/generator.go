@@ -3,25 +3,54 @@ package protoc
 import (
 	"google.golang.org/protobuf/compiler/protogen"
 	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"google.golang.org/protobuf/types/pluginpb"
 )
 
+// SupportedFeatures holds protoc features supported by the plugin:
+// - proto3 optional fields
+// https://github.com/protocolbuffers/protobuf/blob/master/docs/implementing_proto3_presence.md#signaling-that-your-code-generator-supports-proto3-optional
+// - protobuf editions in range from MinimumEdition to MaximumEdition
+// https://protobuf.dev/editions/implementation/
+const SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL | pluginpb.CodeGeneratorResponse_FEATURE_SUPPORTS_EDITIONS)
+
+const (
+	// MinimumEdition is the oldest edition supported by the plugin. proto2 and proto3 are editions as well.
+	MinimumEdition = descriptorpb.Edition_EDITION_PROTO2
+	// MaximumEdition is the newest edition supported by the plugin.
+	MaximumEdition = descriptorpb.Edition_EDITION_2023
+)
+
 // GenerateFileFunc is function that takes generator as input and generate each of the files
 type GenerateFileFunc func(gen *protogen.Plugin, file string) error
 
-// ApplyPluginFunction applies `f` to all FileToGenerate inside request
+// ParamFunc is called for each plugin parameter which is not handled by protogen itself.
+type ParamFunc func(name, value string) error
+
+// NewPlugin creates protogen plugin for provided request and declares all the features supported by the plugin.
+// paramFunc is used to handle plugin parameters, if nil - any plugin specific parameter is an error.
+func NewPlugin(req *pluginpb.CodeGeneratorRequest, paramFunc ParamFunc) (*protogen.Plugin, error) {
+	gen, err := protogen.Options{ParamFunc: paramFunc}.New(req)
+	if err != nil {
+		return nil, err
+	}
+	gen.SupportedFeatures = SupportedFeatures
+	gen.SupportedEditionsMinimum = MinimumEdition
+	gen.SupportedEditionsMaximum = MaximumEdition
+
+	return gen, nil
+}
+
+// ApplyPluginFunction applies `f` to all FileToGenerate inside request, plugin parameters are passed to `paramFunc`.
 //
 // Returns combined plugin response
-func ApplyPluginFunction(f GenerateFileFunc, req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
-	gen, err := protogen.Options{}.New(req)
+func ApplyPluginFunction(f GenerateFileFunc, paramFunc ParamFunc, req *pluginpb.CodeGeneratorRequest) *pluginpb.CodeGeneratorResponse {
+	gen, err := NewPlugin(req, paramFunc)
 	if err != nil {
 		return &pluginpb.CodeGeneratorResponse{
 			Error: proto.String(err.Error()),
 		}
 	}
-	// Add support for proto3 optional fields
-	// https://github.com/protocolbuffers/protobuf/blob/master/docs/implementing_proto3_presence.md#signaling-that-your-code-generator-supports-proto3-optional
-	gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
 
 	for _, file := range gen.Request.FileToGenerate {
 		err := f(gen, file)
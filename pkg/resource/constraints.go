@@ -0,0 +1,421 @@
+package resource
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// fieldConstraints holds OpenAPI schema constraints of message field configured by option
+// `(protoc_gen_resource.field).validation` or comments `+protoc-gen-resource:validation:<name>=<value>`.
+type fieldConstraints struct {
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *int64
+	MaxLength *int64
+	Pattern   string
+	MinItems  *int64
+	MaxItems  *int64
+	Required  bool
+	Nullable  bool
+	Enum      []string
+	Format    string
+	Default   interface{}
+}
+
+// constraint describes single validation of field: how it's configured by option and comments,
+// to which fields it's applicable and how its value is parsed.
+type constraint struct {
+	// marker name of '+protoc-gen-resource:validation:<marker>=' comment.
+	marker string
+	// fromOption returns value configured by option rendered as it's written in comments, empty if not configured.
+	fromOption func(v *protoc_gen_resource.FieldValidation) string
+	// applicable returns true if constraint may be applied to the field.
+	applicable func(field *protogen.Field) bool
+	// applicableTo describes fields constraint is applicable to for error messages.
+	applicableTo string
+	// set parses value and sets it to constraints.
+	set func(field *protogen.Field, c *fieldConstraints, value string) error
+}
+
+var constraints = []constraint{
+	{
+		marker:       "minimum",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatFloat(v.Minimum) },
+		applicable:   isNumberValue,
+		applicableTo: "32 bit integer and floating point number",
+		set:          func(_ *protogen.Field, c *fieldConstraints, value string) error { return parseFloat(value, &c.Minimum) },
+	},
+	{
+		marker:       "maximum",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatFloat(v.Maximum) },
+		applicable:   isNumberValue,
+		applicableTo: "32 bit integer and floating point number",
+		set:          func(_ *protogen.Field, c *fieldConstraints, value string) error { return parseFloat(value, &c.Maximum) },
+	},
+	{
+		marker:       "min-length",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatUint(v.MinLength) },
+		applicable:   isStringValue,
+		applicableTo: "string",
+		set: func(_ *protogen.Field, c *fieldConstraints, value string) error {
+			return parseCount(value, &c.MinLength)
+		},
+	},
+	{
+		marker:       "max-length",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatUint(v.MaxLength) },
+		applicable:   isStringValue,
+		applicableTo: "string",
+		set: func(_ *protogen.Field, c *fieldConstraints, value string) error {
+			return parseCount(value, &c.MaxLength)
+		},
+	},
+	{
+		marker:       "pattern",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return v.GetPattern() },
+		applicable:   isStringValue,
+		applicableTo: "string",
+		set: func(_ *protogen.Field, c *fieldConstraints, value string) error {
+			if _, err := regexp.Compile(value); err != nil {
+				return err
+			}
+			c.Pattern = value
+			return nil
+		},
+	},
+	{
+		marker:       "min-items",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatUint(v.MinItems) },
+		applicable:   func(field *protogen.Field) bool { return field.Desc.IsList() },
+		applicableTo: "repeated",
+		set: func(_ *protogen.Field, c *fieldConstraints, value string) error {
+			return parseCount(value, &c.MinItems)
+		},
+	},
+	{
+		marker:       "max-items",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatUint(v.MaxItems) },
+		applicable:   func(field *protogen.Field) bool { return field.Desc.IsList() },
+		applicableTo: "repeated",
+		set: func(_ *protogen.Field, c *fieldConstraints, value string) error {
+			return parseCount(value, &c.MaxItems)
+		},
+	},
+	{
+		marker:       "required",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatBool(v.GetRequired()) },
+		applicable:   func(*protogen.Field) bool { return true },
+		applicableTo: "all",
+		set:          func(_ *protogen.Field, c *fieldConstraints, value string) error { return parseBool(value, &c.Required) },
+	},
+	{
+		marker:       "nullable",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return formatBool(v.GetNullable()) },
+		applicable:   func(*protogen.Field) bool { return true },
+		applicableTo: "all",
+		set:          func(_ *protogen.Field, c *fieldConstraints, value string) error { return parseBool(value, &c.Nullable) },
+	},
+	{
+		marker:       "enum",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return strings.Join(v.GetEnum(), ",") },
+		applicable:   func(field *protogen.Field) bool { return isStringValue(field) || valueField(field).Enum != nil },
+		applicableTo: "string and enum",
+		set: func(field *protogen.Field, c *fieldConstraints, value string) error {
+			for _, v := range strings.Split(value, ",") {
+				v = strings.TrimSpace(v)
+				if e := valueField(field).Enum; e != nil && e.Desc.Values().ByName(protoreflect.Name(v)) == nil {
+					return fmt.Errorf("'%s' is not a value of enum '%s'", v, e.Desc.FullName())
+				}
+				c.Enum = append(c.Enum, v)
+			}
+			return nil
+		},
+	},
+	{
+		marker:       "format",
+		fromOption:   func(v *protoc_gen_resource.FieldValidation) string { return v.GetFormat() },
+		applicable:   isStringValue,
+		applicableTo: "string",
+		set: func(_ *protogen.Field, c *fieldConstraints, value string) error {
+			c.Format = value
+			return nil
+		},
+	},
+	{
+		marker: "default",
+		fromOption: func(v *protoc_gen_resource.FieldValidation) string {
+			if v.Default == nil {
+				return ""
+			}
+			return *v.Default
+		},
+		applicable:   func(*protogen.Field) bool { return true },
+		applicableTo: "all",
+		set: func(field *protogen.Field, c *fieldConstraints, value string) error {
+			def, err := parseDefault(field, value)
+			if err != nil {
+				return err
+			}
+			c.Default = def
+			return nil
+		},
+	},
+}
+
+// resolveConstraints returns OpenAPI schema constraints of the field configured by option
+// `(protoc_gen_resource.field).validation` or comments `+protoc-gen-resource:validation:<name>=<value>`,
+// value of comment lasts till the end of line.
+// If constraint is not applicable to the type of field, its value is invalid or option and comment disagree -
+// error pointing to proto file and line of the constraint is returned.
+func resolveConstraints(field *protogen.Field) (*fieldConstraints, error) {
+	f := field.Desc.ParentFile()
+	fieldPath := f.SourceLocations().ByDescriptor(field.Desc).Path
+	optionPath := append(append(protoreflect.SourcePath{}, fieldPath...), fieldOptionsField, fieldResourceField)
+	validation := fieldOptions(field).GetValidation()
+
+	c := &fieldConstraints{}
+	for _, con := range constraints {
+		marker := "validation:" + con.marker
+		fromOptions := ""
+		if validation != nil {
+			fromOptions = con.fromOption(validation)
+		}
+		// values of validations like pattern or default may contain spaces
+		fromComments, foundInComments := extractLineMarker(field.Comments.Leading, marker)
+
+		if fromOptions != "" && foundInComments && fromOptions != fromComments {
+			return nil, fmt.Errorf("%s: validation '%s' of field '%s' configured by option '(protoc_gen_resource.field)' '%s' "+
+				"disagrees with validation configured by comments '%s'", position(f, optionPath, fieldPath), con.marker,
+				field.Desc.FullName(), fromOptions, fromComments)
+		}
+
+		value, at := fromOptions, position(f, optionPath, fieldPath)
+		if fromOptions == "" {
+			if !foundInComments {
+				continue
+			}
+			value, at = fromComments, markerPosition(f, marker, fieldPath)
+		}
+
+		if !con.applicable(field) {
+			return nil, fmt.Errorf("%s: validation '%s' can't be applied to field '%s' of type %s, it's applicable only to %s fields",
+				at, con.marker, field.Desc.FullName(), fieldTypeName(field), con.applicableTo)
+		}
+		if err := con.set(field, c, value); err != nil {
+			return nil, fmt.Errorf("%s: invalid validation '%s' value '%s' of field '%s' : %w",
+				at, con.marker, value, field.Desc.FullName(), err)
+		}
+	}
+
+	if err := c.validate(); err != nil {
+		return nil, fmt.Errorf("%s: invalid validation of field '%s' : %w", position(f, fieldPath), field.Desc.FullName(), err)
+	}
+
+	return c, nil
+}
+
+// validate checks that lower bounds of constraints don't exceed upper ones.
+func (c *fieldConstraints) validate() error {
+	if c.Minimum != nil && c.Maximum != nil && *c.Minimum > *c.Maximum {
+		return fmt.Errorf("minimum %v is greater than maximum %v", *c.Minimum, *c.Maximum)
+	}
+	if c.MinLength != nil && c.MaxLength != nil && *c.MinLength > *c.MaxLength {
+		return fmt.Errorf("min-length %d is greater than max-length %d", *c.MinLength, *c.MaxLength)
+	}
+	if c.MinItems != nil && c.MaxItems != nil && *c.MinItems > *c.MaxItems {
+		return fmt.Errorf("min-items %d is greater than max-items %d", *c.MinItems, *c.MaxItems)
+	}
+	return nil
+}
+
+// apply puts constraints to schema of the field. Constraints of values are put to items of repeated fields
+// and to values of maps.
+func (c *fieldConstraints) apply(schema *jsonSchemaProps) {
+	value := schema
+	switch {
+	case schema.Items != nil:
+		value = schema.Items
+	case schema.AdditionalProperties != nil:
+		value = schema.AdditionalProperties
+	}
+
+	value.Minimum = c.Minimum
+	value.Maximum = c.Maximum
+	value.MinLength = c.MinLength
+	value.MaxLength = c.MaxLength
+	value.Pattern = c.Pattern
+	if c.Enum != nil {
+		value.Enum = c.Enum
+	}
+	if c.Format != "" {
+		value.Format = c.Format
+	}
+
+	schema.MinItems = c.MinItems
+	schema.MaxItems = c.MaxItems
+	schema.Nullable = schema.Nullable || c.Nullable
+	schema.Default = c.Default
+}
+
+// valueField returns field describing single value of the field: value of map entry for maps and field itself otherwise.
+// Wrappers are unwrapped to their value field.
+func valueField(field *protogen.Field) *protogen.Field {
+	if field.Desc.IsMap() {
+		field = field.Message.Fields[1]
+	}
+	if field.Message != nil && isWrapper(field.Message) {
+		field = field.Message.Fields[0]
+	}
+	return field
+}
+
+// isWrapper returns true if message is a well-known wrapper of scalar value.
+func isWrapper(m *protogen.Message) bool {
+	return m.Desc.ParentFile().Package() == "google.protobuf" && strings.HasSuffix(string(m.Desc.Name()), "Value") &&
+		m.Desc.Name() != "Value" && m.Desc.Name() != "ListValue"
+}
+
+// isNumberValue returns true if value of the field is integer or floating point number encoded as JSON number.
+// 64 bit integers are encoded as strings by proto3 JSON mapping, so they are not numbers for the schema.
+func isNumberValue(field *protogen.Field) bool {
+	switch valueField(field).Desc.Kind() {
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind,
+		protoreflect.FloatKind, protoreflect.DoubleKind:
+		return true
+	}
+	return false
+}
+
+// isStringValue returns true if value of the field is string.
+func isStringValue(field *protogen.Field) bool {
+	return valueField(field).Desc.Kind() == protoreflect.StringKind
+}
+
+// fieldTypeName returns type of the field for error messages, e.g. "repeated int32" or "map<string, Port>".
+func fieldTypeName(field *protogen.Field) string {
+	typeName := func(field *protogen.Field) string {
+		switch {
+		case field.Message != nil:
+			return string(field.Message.Desc.FullName())
+		case field.Enum != nil:
+			return string(field.Enum.Desc.FullName())
+		}
+		return field.Desc.Kind().String()
+	}
+
+	switch {
+	case field.Desc.IsMap():
+		return fmt.Sprintf("map<%s, %s>", typeName(field.Message.Fields[0]), typeName(field.Message.Fields[1]))
+	case field.Desc.IsList():
+		return "repeated " + typeName(field)
+	}
+	return typeName(field)
+}
+
+// parseDefault returns default value of the field: string as is, value name for enum field, parsed scalar for
+// other scalars and JSON for message, repeated and map fields. 64 bit integers are returned as strings.
+func parseDefault(field *protogen.Field, value string) (interface{}, error) {
+	if field.Desc.IsList() || field.Desc.IsMap() || (field.Message != nil && !isWrapper(field.Message)) {
+		var res interface{}
+		if err := json.Unmarshal([]byte(value), &res); err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+
+	field = valueField(field)
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return value, nil
+	case protoreflect.BoolKind:
+		return strconv.ParseBool(value)
+	case protoreflect.EnumKind:
+		if field.Enum.Desc.Values().ByName(protoreflect.Name(value)) == nil {
+			return nil, fmt.Errorf("'%s' is not a value of enum '%s'", value, field.Enum.Desc.FullName())
+		}
+		return value, nil
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return strconv.ParseFloat(value, 64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return strconv.ParseUint(value, 10, 32)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// 64 bit integers are encoded as strings by proto3 JSON mapping
+		v, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.FormatUint(v, 10), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return strconv.ParseInt(value, 10, 32)
+	default:
+		v, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return strconv.FormatInt(v, 10), nil
+	}
+}
+
+// parseFloat parses number constraint.
+func parseFloat(value string, to **float64) error {
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	*to = &v
+	return nil
+}
+
+// parseCount parses non-negative length or number of items constraint.
+func parseCount(value string, to **int64) error {
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return err
+	}
+	if v < 0 {
+		return fmt.Errorf("must be non-negative")
+	}
+	*to = &v
+	return nil
+}
+
+// parseBool parses flag constraint.
+func parseBool(value string, to *bool) error {
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		return err
+	}
+	*to = v
+	return nil
+}
+
+// formatFloat renders optional number of option as it's written in comments.
+func formatFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'g', -1, 64)
+}
+
+// formatUint renders optional count of option as it's written in comments.
+func formatUint(v *uint64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatUint(*v, 10)
+}
+
+// formatBool renders flag of option as it's written in comments, unset flag is not rendered.
+func formatBool(v bool) string {
+	if !v {
+		return ""
+	}
+	return "true"
+}
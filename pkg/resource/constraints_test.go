@@ -0,0 +1,95 @@
+package resource
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_resolveConstraints(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "invalid_validation.descriptor"), "invalid_validation.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+	message := gen.FilesByPath["invalid_validation.proto"].Messages[0]
+
+	tests := []struct {
+		field   string
+		wantErr string
+	}{
+		{
+			field: "min_length_of_int",
+			wantErr: "invalid_validation.proto:15: validation 'min-length' can't be applied to field " +
+				"'com.example.validation.v1.Invalid.min_length_of_int' of type int32, it's applicable only to string fields",
+		},
+		{
+			field: "minimum_of_string",
+			wantErr: "invalid_validation.proto:18: validation 'minimum' can't be applied to field " +
+				"'com.example.validation.v1.Invalid.minimum_of_string' of type string, " +
+				"it's applicable only to 32 bit integer and floating point number fields",
+		},
+		{
+			field:   "min_items_of_string",
+			wantErr: "invalid_validation.proto:20: validation 'min-items' can't be applied to field",
+		},
+		{
+			field:   "pattern_of_bool",
+			wantErr: "invalid_validation.proto:22: validation 'pattern' can't be applied to field",
+		},
+		{
+			field: "unknown_enum_value",
+			wantErr: "invalid_validation.proto:23: invalid validation 'enum' value 'COLOR_BLUE' of field " +
+				"'com.example.validation.v1.Invalid.unknown_enum_value' : 'COLOR_BLUE' is not a value of enum 'com.example.validation.v1.Color'",
+		},
+		{
+			field:   "invalid_maximum",
+			wantErr: "invalid_validation.proto:25: invalid validation 'maximum' value 'ten'",
+		},
+		{
+			field: "min_greater_than_max",
+			wantErr: "invalid_validation.proto:29: invalid validation of field 'com.example.validation.v1.Invalid.min_greater_than_max' : " +
+				"min-length 5 is greater than max-length 1",
+		},
+		{
+			field:   "invalid_pattern",
+			wantErr: "invalid_validation.proto:30: invalid validation 'pattern' value '[a-'",
+		},
+		{
+			field:   "invalid_default",
+			wantErr: "invalid_validation.proto:32: invalid validation 'default' value 'five'",
+		},
+		{
+			field: "disagree",
+			wantErr: "invalid_validation.proto:35: validation 'format' of field 'com.example.validation.v1.Invalid.disagree' " +
+				"configured by option '(protoc_gen_resource.field)' 'date-time' disagrees with validation configured by comments 'date'",
+		},
+		{
+			field: "minimum_of_int64",
+			wantErr: "invalid_validation.proto:37: validation 'minimum' can't be applied to field " +
+				"'com.example.validation.v1.Invalid.minimum_of_int64' of type int64, " +
+				"it's applicable only to 32 bit integer and floating point number fields",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.field, func(t *testing.T) {
+			for _, field := range message.Fields {
+				if string(field.Desc.Name()) != tt.field {
+					continue
+				}
+
+				_, err := resolveConstraints(field)
+				if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+					t.Errorf("resolveConstraints() error = %v, want prefix %q", err, tt.wantErr)
+				}
+				return
+			}
+			t.Fatalf("field '%s' not found", tt.field)
+		})
+	}
+}
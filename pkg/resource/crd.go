@@ -0,0 +1,220 @@
+package resource
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/compiler/protogen"
+	"k8s.io/apimachinery/pkg/version"
+	"path"
+	"reflect"
+	"sigs.k8s.io/yaml"
+	"sort"
+)
+
+// crdHeader is written at the beginning of each generated CustomResourceDefinition manifest.
+const crdHeader = "# Code generated by protoc-gen-resource. DO NOT EDIT.\n"
+
+// customResourceDefinition is apiextensions.k8s.io/v1 CustomResourceDefinition manifest.
+type customResourceDefinition struct {
+	APIVersion string      `json:"apiVersion"`
+	Kind       string      `json:"kind"`
+	Metadata   crdMetadata `json:"metadata"`
+	Spec       crdSpec     `json:"spec"`
+}
+
+type crdMetadata struct {
+	Name string `json:"name"`
+}
+
+type crdSpec struct {
+	Group    string       `json:"group"`
+	Names    crdNames     `json:"names"`
+	Scope    string       `json:"scope"`
+	Versions []crdVersion `json:"versions"`
+}
+
+type crdNames struct {
+	Kind       string   `json:"kind"`
+	ListKind   string   `json:"listKind"`
+	Plural     string   `json:"plural"`
+	Singular   string   `json:"singular"`
+	ShortNames []string `json:"shortNames,omitempty"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+type crdVersion struct {
+	Name         string           `json:"name"`
+	Served       bool             `json:"served"`
+	Storage      bool             `json:"storage"`
+	Schema       crdValidation    `json:"schema"`
+	Subresources *crdSubresources `json:"subresources,omitempty"`
+}
+
+type crdValidation struct {
+	OpenAPIV3Schema *jsonSchemaProps `json:"openAPIV3Schema"`
+}
+
+type crdSubresources struct {
+	Status *struct{} `json:"status,omitempty"`
+}
+
+// crdResource holds resource of single version of CustomResourceDefinition.
+type crdResource struct {
+	message *protogen.Message
+	gvk     *gvk
+	names   *resourceNames
+}
+
+// generateCRDs generates CustomResourceDefinition manifest for each group and kind of resources of all the files
+// to generate. Each version of kind is served and the version with highest priority by kubernetes rules is storage one.
+// Resources of 'hub' version are internal and never get into CustomResourceDefinition.
+// Manifests are generated once together with the first file to generate as they may combine resources of several files.
+func (p *Params) generateCRDs(gen *protogen.Plugin, file *protogen.File) error {
+	if !p.crdsEnabled() {
+		return nil
+	}
+
+	var files []*protogen.File
+	for _, f := range gen.Files {
+		if f.Generate {
+			files = append(files, f)
+		}
+	}
+	if files[0] != file {
+		return nil
+	}
+
+	kinds := make(map[gvk][]crdResource)
+	for _, f := range files {
+		// go identifiers are not rendered into manifests, so there is no file to qualify them
+		g, err := newGenerator(p, gen, f, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range g.order {
+			if g.modes[m] != modeResource {
+				continue
+			}
+
+			res, err := g.resolveGvk(m)
+			if err != nil {
+				return fmt.Errorf("unable to generate GVK for message '%s' : %w", m.GoIdent.GoName, err)
+			}
+			if res.Version == hubVersion {
+				continue
+			}
+
+			names, err := resolveNames(m, res)
+			if err != nil {
+				return fmt.Errorf("unable to resolve names of message '%s' : %w", m.GoIdent.GoName, err)
+			}
+
+			groupKind := gvk{Group: res.Group, Kind: res.Kind}
+			kinds[groupKind] = append(kinds[groupKind], crdResource{message: m, gvk: res, names: names})
+		}
+	}
+
+	var crds []*customResourceDefinition
+	for _, resources := range kinds {
+		crd, err := newCRD(resources)
+		if err != nil {
+			return err
+		}
+		crds = append(crds, crd)
+	}
+	sort.Slice(crds, func(i, j int) bool {
+		return crds[i].Metadata.Name < crds[j].Metadata.Name
+	})
+
+	for _, crd := range crds {
+		data, err := yaml.Marshal(crd)
+		if err != nil {
+			return fmt.Errorf("unable to marshal CustomResourceDefinition '%s' : %w", crd.Metadata.Name, err)
+		}
+
+		genFile := gen.NewGeneratedFile(path.Join(p.crdDir(), crd.Spec.Group+"_"+crd.Spec.Names.Plural+".yaml"), "")
+		if _, err := genFile.Write(append([]byte(crdHeader), data...)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// newCRD returns CustomResourceDefinition of resources sharing group and kind, each of them is a separate version.
+// Names and scope of all the versions must be the same.
+func newCRD(resources []crdResource) (*customResourceDefinition, error) {
+	sort.Slice(resources, func(i, j int) bool {
+		return version.CompareKubeAwareVersionStrings(resources[i].gvk.Version, resources[j].gvk.Version) > 0
+	})
+
+	first := resources[0]
+	crd := &customResourceDefinition{
+		APIVersion: "apiextensions.k8s.io/v1",
+		Kind:       "CustomResourceDefinition",
+		Metadata:   crdMetadata{Name: first.names.Plural + "." + first.gvk.Group},
+		Spec: crdSpec{
+			Group: first.gvk.Group,
+			Names: crdNames{
+				Kind:       first.gvk.Kind,
+				ListKind:   first.gvk.Kind + listSuffix,
+				Plural:     first.names.Plural,
+				Singular:   first.names.Singular,
+				ShortNames: first.names.ShortNames,
+				Categories: first.names.Categories,
+			},
+			Scope: first.names.Scope,
+		},
+	}
+
+	for i, r := range resources {
+		if i > 0 && r.gvk.Version == resources[i-1].gvk.Version {
+			return nil, fmt.Errorf("messages '%s' and '%s' have the same group '%s', version '%s' and kind '%s'",
+				resources[i-1].message.Desc.FullName(), r.message.Desc.FullName(), r.gvk.Group, r.gvk.Version, r.gvk.Kind)
+		}
+		if !reflect.DeepEqual(r.names, first.names) {
+			return nil, fmt.Errorf("%s: names or scope of message '%s' %+v disagree with names or scope of message '%s' %+v "+
+				"of the same group '%s' and kind '%s'", position(r.message.Desc.ParentFile(), messagePath(r.message)),
+				r.message.Desc.FullName(), *r.names, first.message.Desc.FullName(), *first.names, r.gvk.Group, r.gvk.Kind)
+		}
+
+		v, err := newCRDVersion(r, i == 0)
+		if err != nil {
+			return nil, fmt.Errorf("unable to generate CustomResourceDefinition version of message '%s' : %w",
+				r.message.GoIdent.GoName, err)
+		}
+		crd.Spec.Versions = append(crd.Spec.Versions, v)
+	}
+
+	return crd, nil
+}
+
+// newCRDVersion returns version of CustomResourceDefinition with schema of resource.
+// Status subresource is enabled if resource has status field.
+func newCRDVersion(r crdResource, storage bool) (crdVersion, error) {
+	schema, err := resourceSchema(r.message)
+	if err != nil {
+		return crdVersion{}, err
+	}
+
+	v := crdVersion{
+		Name:    r.gvk.Version,
+		Served:  true,
+		Storage: storage,
+		Schema:  crdValidation{OpenAPIV3Schema: schema},
+	}
+
+	status, err := roleField(r.message, fieldRoleStatus)
+	if err != nil {
+		return crdVersion{}, err
+	}
+	if status != nil {
+		if status.Desc.JSONName() != fieldRoleStatus {
+			return crdVersion{}, fmt.Errorf("%s: status field '%s' of message '%s' must have JSON name '%s' to be served by status subresource",
+				fieldPosition(status), status.Desc.Name(), r.message.GoIdent.GoName, fieldRoleStatus)
+		}
+		v.Subresources = &crdSubresources{Status: &struct{}{}}
+	}
+
+	return v, nil
+}
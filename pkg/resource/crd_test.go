@@ -0,0 +1,162 @@
+package resource
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestGenerate_crds checks that CustomResourceDefinition of each group and kind combines versions of all the files.
+func TestGenerate_crds(t *testing.T) {
+	crdFiles := []string{"crd/v1.proto", "crd/v1beta1.proto"}
+	tests := []struct {
+		name           string
+		descriptorPath string
+		files          []string
+		parameter      string
+		want           map[string]string
+	}{
+		{
+			name:           "Enabled",
+			descriptorPath: filepath.Join("testdata", "descriptors", "crd.descriptor"),
+			files:          crdFiles,
+			parameter:      "crds=true,version_accessor=GetAPIVersion",
+			want: map[string]string{
+				"crds/crd.example.com_gadgets.yaml": filepath.Join("testdata", "etalons", "crd.example.com_gadgets.yaml.etalone"),
+				"crds/crd.example.com_widgets.yaml": filepath.Join("testdata", "etalons", "crd.example.com_widgets.yaml.etalone"),
+			},
+		},
+		{
+			name:           "Custom Directory",
+			descriptorPath: filepath.Join("testdata", "descriptors", "crd.descriptor"),
+			files:          crdFiles,
+			parameter:      "crds=true,crd_dir=config/crd,version_accessor=GetAPIVersion",
+			want: map[string]string{
+				"config/crd/crd.example.com_gadgets.yaml": filepath.Join("testdata", "etalons", "crd.example.com_gadgets.yaml.etalone"),
+				"config/crd/crd.example.com_widgets.yaml": filepath.Join("testdata", "etalons", "crd.example.com_widgets.yaml.etalone"),
+			},
+		},
+		{
+			name:           "Disabled",
+			descriptorPath: filepath.Join("testdata", "descriptors", "crd.descriptor"),
+			files:          crdFiles,
+			parameter:      "version_accessor=GetAPIVersion",
+			want:           map[string]string{},
+		},
+		{
+			name:           "Validation",
+			descriptorPath: filepath.Join("testdata", "descriptors", "validation.descriptor"),
+			files:          []string{"validation.proto"},
+			parameter:      "crds=true",
+			want: map[string]string{
+				"crds/validation.example.com_servers.yaml": filepath.Join("testdata", "etalons", "validation.example.com_servers.yaml.etalone"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := protoc.ReadCodeGenerationRequest(tt.descriptorPath, tt.files...)
+			assert.NilError(t, err, "unable to create code generation request")
+			req.Parameter = proto.String(tt.parameter)
+
+			params := &Params{}
+			gen, err := protoc.NewPlugin(req, params.Set)
+			assert.NilError(t, err, "unable to create protogen plugin")
+
+			for _, file := range tt.files {
+				assert.NilError(t, params.Generate(gen, file))
+			}
+
+			got := map[string]string{}
+			for _, f := range gen.Response().File {
+				if path.Ext(f.GetName()) == ".yaml" {
+					got[f.GetName()] = f.GetContent()
+				}
+			}
+
+			want := map[string]string{}
+			for name, etalon := range tt.want {
+				data, err := os.ReadFile(etalon)
+				assert.NilError(t, err)
+				want[name] = string(data)
+			}
+
+			assert.DeepEqual(t, want, got)
+		})
+	}
+}
+
+func Test_newCRD(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "invalid_crd.descriptor"), "invalid_crd.proto")
+	assert.NilError(t, err, "unable to create code generation request")
+	gen, err := protoc.NewPlugin(req, nil)
+	assert.NilError(t, err, "unable to create protogen plugin")
+	file := gen.FilesByPath["invalid_crd.proto"]
+
+	g, err := newGenerator(&Params{}, gen, file, nil)
+	assert.NilError(t, err, "unable to create generator")
+
+	tests := []struct {
+		name     string
+		messages []string
+		wantErr  string
+	}{
+		{
+			name:     "Names Disagree",
+			messages: []string{"ThingV1", "ThingV2"},
+			wantErr: "invalid_crd.proto:15: names or scope of message 'com.example.crd.v1.ThingV1' " +
+				"{Plural:things Singular:thing ShortNames:[] Categories:[] Scope:Namespaced} disagree with names or scope of message " +
+				"'com.example.crd.v1.ThingV2' {Plural:thingies Singular:thing ShortNames:[] Categories:[] Scope:Namespaced} " +
+				"of the same group 'crd.example.com' and kind 'Thing'",
+		},
+		{
+			name:     "Same Version",
+			messages: []string{"ThingV1", "OtherThingV1"},
+			wantErr:  "have the same group 'crd.example.com', version 'v1' and kind 'Thing'",
+		},
+		{
+			name:     "Status JSON Name",
+			messages: []string{"ObservedStatus"},
+			wantErr: "unable to generate CustomResourceDefinition version of message 'ObservedStatus' : invalid_crd.proto:36: " +
+				"status field 'observed' of message 'ObservedStatus' must have JSON name 'status' to be served by status subresource",
+		},
+		{
+			name:     "Kind Property",
+			messages: []string{"KindProperty"},
+			wantErr: "unable to generate CustomResourceDefinition version of message 'KindProperty' : invalid_crd.proto:40: " +
+				"field 'kind' of message 'KindProperty' clashes with 'kind' property of resource",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			var resources []crdResource
+			for _, name := range tt.messages {
+				var m *protogen.Message
+				for _, candidate := range file.Messages {
+					if candidate.GoIdent.GoName == name {
+						m = candidate
+					}
+				}
+
+				res, err := g.resolveGvk(m)
+				assert.NilError(t, err)
+				names, err := resolveNames(m, res)
+				assert.NilError(t, err)
+				resources = append(resources, crdResource{message: m, gvk: res, names: names})
+			}
+
+			_, err := newCRD(resources)
+			if err == nil || !strings.HasSuffix(err.Error(), tt.wantErr) {
+				t.Errorf("newCRD() error = %v, want suffix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -14,13 +14,58 @@ var deepCopyTmpl string
 //go:embed templates/deepcopy_object.gotmpl
 var deepCopyObjectTmpl string
 
+// protoPackage holds import path of protobuf runtime package, used for proto.Clone.
+const protoPackage = protogen.GoImportPath("google.golang.org/protobuf/proto")
+
+// protoreflectPackage holds import path of protobuf reflection package, used to copy extensions.
+const protoreflectPackage = protogen.GoImportPath("google.golang.org/protobuf/reflect/protoreflect")
+
+// Strategies of message copy. They are written as comments into generated code, so it's clear how each field is copied.
+const (
+	copyStrategyWellKnown = "well-known type, copied in place"
+	copyStrategyDeepCopy  = "message with generated deepcopy, DeepCopy is used"
+	copyStrategyClone     = "message without generated deepcopy, proto.Clone is used"
+)
+
+// messageCopy returns go expression which copies non-nil message `in` and the strategy which was chosen for it.
+// 1) if message is one of well-known types - it's copied in place, see wellKnownCopy
+// 2) if message is generated in the same plugin run - it's 1rst party message and has generated DeepCopy, so just call it
+// 3) if message is shipped in protoc_gen_resource/meta/v1/meta.proto - it has checked in DeepCopy, so just call it
+// 4) else - we are not sure were deepcopy functions generated for message (e.g. 3rd party messages),
+// so fall back to proto.Clone and assert result back to message type.
+func (g *generator) messageCopy(message *protogen.Message, in string) (string, string) {
+	if copyExpr, ok := g.wellKnownCopy(message, in); ok {
+		return copyExpr, copyStrategyWellKnown
+	}
+	if _, ok := g.firstPartyMessages[message]; ok {
+		return in + ".DeepCopy()", copyStrategyDeepCopy
+	}
+	if message.Desc.ParentFile().Package() == metaProtoPackage {
+		return in + ".DeepCopy()", copyStrategyDeepCopy
+	}
+	return fmt.Sprintf("%s(%s).(*%s)",
+		g.genFile.QualifiedGoIdent(protoPackage.Ident("Clone")), in, g.genFile.QualifiedGoIdent(message.GoIdent)), copyStrategyClone
+}
+
 // deepCopyIntoMessage generates DeepCopyInto function. Fields will be processed in exactly same order as they present in message.
 func (g *generator) deepCopyIntoMessage(message *protogen.Message) {
 	g.sw.Do("// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.\n", nil)
 	g.sw.Do("func (in *{{.GoIdent.GoName}}) DeepCopyInto(out *{{.GoIdent.GoName}}) {\n", message)
 	for _, field := range message.Fields {
+		// oneof fields are processed all together on the first field of the oneof.
+		// proto3 optionals are also oneofs, but synthetic ones - they are processed as plain fields.
+		if oneof := field.Oneof; oneof != nil && !oneof.Desc.IsSynthetic() {
+			if oneof.Fields[0] == field {
+				g.doOneof(oneof)
+			}
+			continue
+		}
 		g.doField(field)
 	}
+	if message.Desc.ExtensionRanges().Len() > 0 {
+		g.doExtensions(message)
+	}
+	g.doUnknownFields()
 	g.sw.Do("return\n", nil)
 	g.sw.Do("}\n\n", nil)
 }
@@ -28,6 +73,12 @@ func (g *generator) deepCopyIntoMessage(message *protogen.Message) {
 // doField process single message field.
 func (g *generator) doField(field *protogen.Field) {
 
+	// map is a message kind with a flag on description - so check it first
+	if field.Desc.IsMap() {
+		g.doMap(field)
+		return
+	}
+
 	// list just a flag on description - so check it first
 	if field.Desc.IsList() {
 		g.doList(field)
@@ -43,7 +94,8 @@ func (g *generator) doField(field *protogen.Field) {
 		g.doEnum(field)
 	case protoreflect.BytesKind:
 		g.doBytes(field)
-	case protoreflect.MessageKind:
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// proto2 groups and editions delimited messages are just messages with another wire encoding
 		g.doMessage(field)
 	default:
 		panic(fmt.Errorf("kind '%s' not supported yet", field.Desc.Kind()))
@@ -55,55 +107,215 @@ func (g *generator) doList(field *protogen.Field) {
 	switch field.Desc.Kind() {
 	case protoreflect.BoolKind, protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind,
 		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind, protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind, protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind,
-		protoreflect.DoubleKind, protoreflect.StringKind, protoreflect.BytesKind:
+		protoreflect.DoubleKind, protoreflect.StringKind:
 		g.doScalarList(field)
+	case protoreflect.BytesKind:
+		g.doBytesList(field)
 	case protoreflect.EnumKind:
 		g.doEnumList(field)
-	case protoreflect.MessageKind:
+	case protoreflect.MessageKind, protoreflect.GroupKind:
 		g.doMessageList(field)
 	default:
 		panic(fmt.Errorf("kind '%s' not supported yet", field.Desc.Kind()))
 	}
 }
 
-// doBytes process bytes fields. bytes are just scalar types, so simple assignment.
-func (g *generator) doBytes(field *protogen.Field) {
-	g.sw.Do("out.{{ .field.GoName }} = in.{{ .field.GoName }}\n", templates.Args{"field": field})
+// doExtensions process proto2 extensions. Extension values are stored in internal extension map of the message,
+// so they are copied by reflection: all the extensions are collected into a temporary message, which is deep copied by
+// proto.Clone, and values of the clone are set into out.
+func (g *generator) doExtensions(message *protogen.Message) {
+	g.sw.Do(`
+// extensions are stored in internal extension map, so copy them by reflection
+extensions := new({{ .message.GoIdent.GoName }}).ProtoReflect()
+in.ProtoReflect().Range(func(fd {{ .fieldDescriptor }}, v {{ .value }}) bool {
+	if fd.IsExtension() {
+		extensions.Set(fd, v)
+	}
+	return true
+})
+{{ .clone }}(extensions.Interface()).ProtoReflect().Range(func(fd {{ .fieldDescriptor }}, v {{ .value }}) bool {
+	out.ProtoReflect().Set(fd, v)
+	return true
+})
+`, templates.Args{
+		"message":         message,
+		"clone":           g.genFile.QualifiedGoIdent(protoPackage.Ident("Clone")),
+		"fieldDescriptor": g.genFile.QualifiedGoIdent(protoreflectPackage.Ident("FieldDescriptor")),
+		"value":           g.genFile.QualifiedGoIdent(protoreflectPackage.Ident("Value")),
+	})
 }
 
-// doMessage process messages. Here we have following
-// 1) if message is located in the same proto file - it's 1rst party message, and we can just call DeepCopyInto for it
-// 2) else - we are not sure were runtime.Object functions implemented there - so cast to runtime.Object
-// 2.1) If case successful - just call conversion func
-// 2.2) If it not implements runtime.Object (e.g. 3rd party google messages) - generate DeepCopy for it right here.
-func (g *generator) doMessage(field *protogen.Field) {
+// doUnknownFields process unknown fields of the message, so fields written by a newer schema version are not lost.
+// Only unknown fields bytes are copied, internal message state and size cache are never copied.
+func (g *generator) doUnknownFields() {
+	g.sw.Do(`
+// unknown fields are kept, message state and size cache are not copied
+if in.unknownFields != nil {
+	in, out := &in.unknownFields, &out.unknownFields
+	*out = make([]byte, len(*in))
+	copy(*out, *in)
+}
+`, nil)
+}
 
-	// TODO process 1rst party messages
-	// TODO process 3rd party messages
+// doOneof process oneof. protoc-gen-go stores oneof as interface field holding one of per-case wrapper structs.
+// So switch on the type of the wrapper and copy the active case into a new wrapper.
+// Inside each case `in` and `out` are shadowed by the wrappers, so the case field is processed like any other field.
+func (g *generator) doOneof(oneof *protogen.Oneof) {
+	g.sw.Do(`
+if in.{{ .oneof.GoName }} != nil {
+	switch in := in.{{ .oneof.GoName }}.(type) {
+`, templates.Args{"oneof": oneof})
+	for _, field := range oneof.Fields {
+		g.sw.Do(`case *{{ .field.GoIdent.GoName }}:
+	outCase := new({{ .field.GoIdent.GoName }})
+	out.{{ .oneof.GoName }} = outCase
+	out := outCase
+`, templates.Args{"field": field, "oneof": oneof})
+		g.doField(field)
+	}
+	g.sw.Do(`}
+}
+`, nil)
+}
+
+// doMap process map fields. Map keys are always scalars, so only the value kind matters.
+// nil map stays nil, empty map stays empty.
+func (g *generator) doMap(field *protogen.Field) {
+	value := field.Message.Fields[1]
+	switch value.Desc.Kind() {
+	case protoreflect.BoolKind, protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind, protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind, protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind,
+		protoreflect.DoubleKind, protoreflect.StringKind, protoreflect.EnumKind:
+		g.doScalarMap(field)
+	case protoreflect.BytesKind:
+		g.doBytesMap(field)
+	case protoreflect.MessageKind:
+		g.doMessageMap(field)
+	default:
+		panic(fmt.Errorf("map value kind '%s' not supported yet", value.Desc.Kind()))
+	}
+}
+
+// doScalarMap process maps with scalar or enum values. Values are copied by assignment.
+func (g *generator) doScalarMap(field *protogen.Field) {
+	g.sw.Do(`
+if in.{{ .field.GoName }} != nil {
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = make({{ .field | GoType }}, len(*in))
+	for key, val := range *in {
+		(*out)[key] = val
+	}
+}
+`, templates.Args{"field": field})
+}
+
+// doBytesMap process maps with bytes values. Each value gets its own backing array, nil values stay nil.
+func (g *generator) doBytesMap(field *protogen.Field) {
+	g.sw.Do(`
+if in.{{ .field.GoName }} != nil {
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = make({{ .field | GoType }}, len(*in))
+	for key, val := range *in {
+		var outVal []byte
+		if val != nil {
+			outVal = make([]byte, len(val))
+			copy(outVal, val)
+		}
+		(*out)[key] = outVal
+	}
+}
+`, templates.Args{"field": field})
+}
+
+// doMessageMap process maps with message values. Values are copied the same way as singular messages, see messageCopy.
+func (g *generator) doMessageMap(field *protogen.Field) {
+	value := field.Message.Fields[1]
+	copyExpr, strategy := g.messageCopy(value.Message, "val")
+	g.sw.Do(`
+// {{ .field.GoName }}: {{ .strategy }}
+if in.{{ .field.GoName }} != nil {
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = make({{ .field | GoType }}, len(*in))
+	for key, val := range *in {
+		var outVal {{ .value | GoType }}
+		if val != nil {
+			outVal = {{ .copy }}
+		}
+		(*out)[key] = outVal
+	}
+}
+`, templates.Args{"field": field, "value": value, "copy": copyExpr, "strategy": strategy})
+}
+
+// doBytes process bytes fields. Bytes are copied into a new slice, so the copy never shares backing array with
+// the original. nil stays nil and empty stays empty - it also keeps presence of bytes with presence (e.g. optionals).
+func (g *generator) doBytes(field *protogen.Field) {
 	g.sw.Do(`if in.{{ .field.GoName }} != nil {
-        _, ok := interface{}(in.{{ .field.GoName }}).(runtime.Object)
-        if ok {
-          out.{{ .field.GoName }} = in.{{ .field.GoName }}.DeepCopy()
-        } else {
-          panic(fmt.Errorf("message field '{{.message.GoIdent.GoName}}{{ .field.GoName }}' does not implement runtime.Object"))
-        }
-	}`, templates.Args{"field": field, "message": field.Parent})
-	g.sw.Do("\n", nil)
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = make([]byte, len(*in))
+	copy(*out, *in)
+}
+`, templates.Args{"field": field})
+}
+
+// doMessage process messages. Message is copied by expression built by messageCopy.
+func (g *generator) doMessage(field *protogen.Field) {
+	copyExpr, strategy := g.messageCopy(field.Message, "in."+field.GoName)
+	g.sw.Do(`// {{ .field.GoName }}: {{ .strategy }}
+if in.{{ .field.GoName }} != nil {
+	out.{{ .field.GoName }} = {{ .copy }}
+}
+`, templates.Args{"field": field, "copy": copyExpr, "strategy": strategy})
 }
 
 // doEnum process enums fields. Enums are just scalar types, so simple assignment.
+// Enums with presence are processed the same way as scalars with presence.
+// Open and closed enums have the same go representation, so they are processed the same way.
 func (g *generator) doEnum(field *protogen.Field) {
+	if isPointer(field) {
+		g.doPointer(field)
+		return
+	}
 	g.sw.Do("out.{{ .field.GoName }} = in.{{ .field.GoName }}\n", templates.Args{"field": field})
 }
 
-// doScalar process scalars types. We support protobuf 3 optionals - also processing optional keyword.
+// doScalar process scalars types. Scalars with presence (e.g. protobuf 3 optionals) are stored as pointers.
 func (g *generator) doScalar(field *protogen.Field) {
-	if field.Desc.HasOptionalKeyword() {
-		g.sw.Do("{{ .field.GoName }} := *in.{{ .field.GoName }}\n", templates.Args{"field": field})
-		g.sw.Do("out.{{ .field.GoName }} = &{{ .field.GoName }}\n", templates.Args{"field": field})
-	} else {
-		g.sw.Do("out.{{ .field.GoName }} = in.{{ .field.GoName }}\n", templates.Args{"field": field})
+	if isPointer(field) {
+		g.doPointer(field)
+		return
 	}
+	g.sw.Do("out.{{ .field.GoName }} = in.{{ .field.GoName }}\n", templates.Args{"field": field})
+}
+
+// doPointer process scalars and enums stored as pointers. Presence is kept exactly:
+// unset field stays nil, set field gets a fresh pointer.
+func (g *generator) doPointer(field *protogen.Field) {
+	g.sw.Do(`if in.{{ .field.GoName }} != nil {
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = new({{ .field | GoType }})
+	**out = **in
+}
+`, templates.Args{"field": field})
+}
+
+// isPointer returns true if field with scalar or enum kind is stored as pointer in generated go struct.
+// It's the case for fields with presence, except members of oneof which are stored in wrappers by value.
+// Presence is taken from descriptor, so it's resolved from syntax or editions features (explicit, implicit or legacy required).
+// Bytes are not stored as pointers - for them nil slice means unset field.
+func isPointer(field *protogen.Field) bool {
+	if !field.Desc.HasPresence() || field.Desc.IsList() || field.Desc.IsMap() {
+		return false
+	}
+	if oneof := field.Oneof; oneof != nil && !oneof.Desc.IsSynthetic() {
+		return false
+	}
+	switch field.Desc.Kind() {
+	case protoreflect.BytesKind, protoreflect.MessageKind, protoreflect.GroupKind:
+		return false
+	}
+	return true
 }
 
 // doScalarList process repeatable scalars. Repeatable scalars are not optional, so no need to check it.
@@ -117,32 +329,45 @@ if in.{{ .field.GoName }} != nil {
 `, templates.Args{"field": field})
 }
 
-func (g *generator) doMessageList(field *protogen.Field) {
+// doBytesList process repeated bytes. Each element is copied into a new slice, nil elements stay nil.
+func (g *generator) doBytesList(field *protogen.Field) {
+	g.sw.Do(`
+if in.{{ .field.GoName }} != nil {
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = make({{ .field | GoType }}, len(*in))
+	for i := range *in {
+		if (*in)[i] != nil {
+			(*out)[i] = make([]byte, len((*in)[i]))
+			copy((*out)[i], (*in)[i])
+		}
+	}
+}
+`, templates.Args{"field": field})
+}
 
+// doMessageList process repeated messages. Each message is copied the same way as singular messages, see messageCopy.
+func (g *generator) doMessageList(field *protogen.Field) {
+	copyExpr, strategy := g.messageCopy(field.Message, "(*in)[i]")
 	g.sw.Do(`
-	inn, outt := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
-	*outt = make([]*{{ .field.Message.GoIdent.GoName }}, len(*inn))
-	for i := range *inn {
-		if (*inn)[i] != nil {
-			in, out := &(*inn)[i], &(*outt)[i]
-			_, ok := interface{}(*in).(runtime.Object)
-			if ok {
-            	*out = new({{ .field.Message.GoIdent.GoName }})
-				(*in).DeepCopyInto(*out)
-			} else {
-				panic(fmt.Errorf("message field '{{.message.GoIdent.GoName}}{{ .field.GoName }}' does not implement runtime.Object"))
-			}
-		}            
+// {{ .field.GoName }}: {{ .strategy }}
+if in.{{ .field.GoName }} != nil {
+	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
+	*out = make({{ .field | GoType }}, len(*in))
+	for i := range *in {
+		if (*in)[i] != nil {
+			(*out)[i] = {{ .copy }}
+		}
 	}
-`, templates.Args{"field": field, "message": field.Parent})
+}
+`, templates.Args{"field": field, "copy": copyExpr, "strategy": strategy})
 }
 
-// doScalarList process repeatable scalars. Repeatable scalars are not optional, so no need to check it.
+// doEnumList process repeatable enums. Repeatable enums are not optional, so no need to check it.
 func (g *generator) doEnumList(field *protogen.Field) {
 	g.sw.Do(`
 if in.{{ .field.GoName }} != nil {
 	in, out := &in.{{ .field.GoName }}, &out.{{ .field.GoName }}
-	*out = make([]{{ .field.Enum.GoIdent.GoName }}, len(*in))
+	*out = make({{ .field | GoType }}, len(*in))
 	copy(*out, *in)
 }
 `, templates.Args{"field": field})
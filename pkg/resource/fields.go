@@ -0,0 +1,105 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// Roles of resource fields.
+const (
+	// fieldRoleMetadata field holds object metadata.
+	fieldRoleMetadata = "metadata"
+	// fieldRoleSpec field holds desired state of resource.
+	fieldRoleSpec = "spec"
+	// fieldRoleStatus field holds observed state of resource.
+	fieldRoleStatus = "status"
+)
+
+// roleField returns field of resource with provided role configured by option `(protoc_gen_resource.field).role` or
+// comment `+protoc-gen-resource:field=ROLE`. If there is no such field - nil is returned.
+// Field must be singular message field and the only one with the role in resource.
+func roleField(m *protogen.Message, role string) (*protogen.Field, error) {
+	var res *protogen.Field
+	for _, field := range m.Fields {
+		fieldRole, err := fieldRole(field)
+		if err != nil {
+			return nil, err
+		}
+		if fieldRole != role {
+			continue
+		}
+
+		at := fieldPosition(field)
+		if res != nil {
+			return nil, fmt.Errorf("%s: message '%s' has more than one %s field: '%s' and '%s'",
+				at, m.GoIdent.GoName, role, res.Desc.Name(), field.Desc.Name())
+		}
+		if field.Message == nil || !isSingular(field) {
+			return nil, fmt.Errorf("%s: %s field '%s' of message '%s' must be singular message field out of oneof",
+				at, role, field.Desc.Name(), m.GoIdent.GoName)
+		}
+		res = field
+	}
+
+	return res, nil
+}
+
+// fieldRole returns role of the field configured by option `(protoc_gen_resource.field).role` or comment
+// `+protoc-gen-resource:field=[metadata|spec|status]`. If role is not configured - empty string is returned.
+// If option and comment disagree - error is returned.
+func fieldRole(field *protogen.Field) (string, error) {
+	var fromOptions string
+	switch fieldOptions(field).GetRole() {
+	case protoc_gen_resource.FieldRole_FIELD_ROLE_METADATA:
+		fromOptions = fieldRoleMetadata
+	case protoc_gen_resource.FieldRole_FIELD_ROLE_SPEC:
+		fromOptions = fieldRoleSpec
+	case protoc_gen_resource.FieldRole_FIELD_ROLE_STATUS:
+		fromOptions = fieldRoleStatus
+	}
+
+	fromComments, foundInComments := extractMarker(field.Comments.Leading, "field")
+	if foundInComments && fromComments != fieldRoleMetadata && fromComments != fieldRoleSpec && fromComments != fieldRoleStatus {
+		f := field.Desc.ParentFile()
+		return "", fmt.Errorf("%s: invalid comment '+protoc-gen-resource:field=%s' of field '%s', role must be one of '%s', '%s', '%s'",
+			markerPosition(f, "field", f.SourceLocations().ByDescriptor(field.Desc).Path), fromComments,
+			field.Desc.FullName(), fieldRoleMetadata, fieldRoleSpec, fieldRoleStatus)
+	}
+
+	if fromOptions != "" && foundInComments && fromOptions != fromComments {
+		return "", fmt.Errorf("role of field '%s' configured by option '(protoc_gen_resource.field)' '%s' "+
+			"disagrees with role configured by comments '%s'", field.Desc.FullName(), fromOptions, fromComments)
+	}
+
+	if fromOptions != "" {
+		return fromOptions, nil
+	}
+
+	return fromComments, nil
+}
+
+// fieldOptions returns `(protoc_gen_resource.field)` option of the field or nil if it's not set.
+func fieldOptions(field *protogen.Field) *protoc_gen_resource.FieldResourceOptions {
+	opts, ok := field.Desc.Options().(*descriptorpb.FieldOptions)
+	if !ok || !proto.HasExtension(opts, protoc_gen_resource.E_Field) {
+		return nil
+	}
+
+	return proto.GetExtension(opts, protoc_gen_resource.E_Field).(*protoc_gen_resource.FieldResourceOptions)
+}
+
+// fieldPosition returns '<proto file>:<line>' of the field.
+func fieldPosition(field *protogen.Field) string {
+	f := field.Desc.ParentFile()
+	return position(f, f.SourceLocations().ByDescriptor(field.Desc).Path)
+}
+
+// isSingular returns true if field is neither list nor map and is not a member of oneof, so it's a plain struct field.
+// Proto3 optional fields are singular as their oneof is synthetic.
+func isSingular(field *protogen.Field) bool {
+	inOneof := field.Oneof != nil && !field.Oneof.Desc.IsSynthetic()
+	return !field.Desc.IsList() && !field.Desc.IsMap() && !inOneof
+}
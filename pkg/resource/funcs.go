@@ -6,16 +6,20 @@ import (
 	"google.golang.org/protobuf/reflect/protoreflect"
 )
 
-// GoType returns corresponding go type of the field
-func GoType(f *protogen.Field) string {
+// GoType returns corresponding go type of the field.
+// Enums and messages are qualified by the generated file, so the required imports will be added.
+func GoType(gen *protogen.GeneratedFile, f *protogen.Field) string {
 
+	if f.Desc.IsMap() {
+		return "map[" + getUnderlingTypeName(gen, f.Message.Fields[0]) + "]" + getUnderlingTypeName(gen, f.Message.Fields[1])
+	}
 	if f.Desc.IsList() {
-		return "[]" + getUnderlingTypeName(f)
+		return "[]" + getUnderlingTypeName(gen, f)
 	}
-	return getUnderlingTypeName(f)
+	return getUnderlingTypeName(gen, f)
 }
 
-func getUnderlingTypeName(f *protogen.Field) string {
+func getUnderlingTypeName(gen *protogen.GeneratedFile, f *protogen.Field) string {
 
 	switch f.Desc.Kind() {
 	// scalars first
@@ -38,7 +42,11 @@ func getUnderlingTypeName(f *protogen.Field) string {
 	case protoreflect.BytesKind:
 		return "[]byte"
 	// scalars end
+	case protoreflect.EnumKind:
+		return gen.QualifiedGoIdent(f.Enum.GoIdent)
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return "*" + gen.QualifiedGoIdent(f.Message.GoIdent)
 	default:
-		panic(fmt.Sprintf("type '%+v' is not supported for conversion to go type"))
+		panic(fmt.Sprintf("type '%+v' is not supported for conversion to go type", f.Desc.Kind()))
 	}
 }
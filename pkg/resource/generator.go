@@ -14,25 +14,46 @@ import (
 var packageTmpl string
 
 type generator struct {
-	// firstPartyMessages messages which are present in file to generate.
+	// firstPartyMessages messages which are present in files to generate in the current plugin run and not skipped.
 	// for these messages we'll not generate copy inside other messages, just call
 	// already generated deepcopy functions.
 	firstPartyMessages map[*protogen.Message]interface{}
 
+	// modes holds mode of each message from files to generate in the current plugin run.
+	modes map[*protogen.Message]messageMode
+
 	// order in which messages will be generated.
 	order []*protogen.Message
 
 	// sw contains generated file content.
 	sw *templates.SnippetWriter
 
+	// genFile is the file being generated. It's used to qualify go identifiers from other packages.
+	genFile *protogen.GeneratedFile
+
+	// file holds protobuf file to generate.
+	file *protogen.File
+
 	// protoPackage holds protobuf package.
 	protoPackage string
 
+	// mapping holds rules to get group and version from protobuf package.
+	mapping PackageMapping
+
 	// goPackage golds go package.
 	goPackage string
+
+	// accessors holds names of generated methods returning group, version and kind of resource.
+	accessors gvkAccessors
 }
 
+// Generate generates resource methods for the file with default plugin parameters.
 func Generate(gen *protogen.Plugin, filePath string) error {
+	return (&Params{}).Generate(gen, filePath)
+}
+
+// Generate generates resource methods for the file.
+func (p *Params) Generate(gen *protogen.Plugin, filePath string) error {
 
 	file := gen.FilesByPath[filePath]
 
@@ -41,36 +62,47 @@ func Generate(gen *protogen.Plugin, filePath string) error {
 		file.GoImportPath,
 	)
 
-	generator := newGenerator(file)
+	generator, err := newGenerator(p, gen, file, genFile)
+	if err != nil {
+		return err
+	}
 
-	// if no messages - skip generation
+	// if no messages - skip generation of deepcopy file,
+	// register helpers and manifests still have to be generated as they cover other files as well
 	if len(generator.order) == 0 {
 		genFile.Skip()
-		return nil
-	}
+	} else {
+		// generate package and imports
+		err = generator.generate()
+		if err != nil {
+			return err
+		}
 
-	// generate package and imports
-	err := generator.generate()
-	if err != nil {
-		return err
-	}
+		sources := []byte(fmt.Sprintf("%v", generator.sw.Out()))
 
-	sources := []byte(fmt.Sprintf("%v", generator.sw.Out()))
+		formattedSources, err := format.Source(sources)
+		if err != nil {
+			return fmt.Errorf("unable to format generated sources : %w", err)
+		}
 
-	formattedSources, err := format.Source(sources)
-	if err != nil {
-		return fmt.Errorf("unable to format generated sources : %w", err)
+		_, err = genFile.Write(formattedSources)
+		if err != nil {
+			return err
+		}
 	}
 
-	_, err = genFile.Write(formattedSources)
+	err = p.generateRegister(gen, file)
+	if err != nil {
+		return err
+	}
 
-	return err
+	return p.generateCRDs(gen, file)
 }
 
 // generate all the deepcopy file content.
 func (g *generator) generate() error {
 	// init package and imports
-	g.sw.Do(packageTmpl, templates.Args{"package": g.goPackage})
+	g.sw.Do(packageTmpl, templates.Args{"package": g.goPackage, "resources": g.hasResources()})
 	// process all the messages
 
 	for _, m := range g.order {
@@ -82,34 +114,107 @@ func (g *generator) generate() error {
 	return g.sw.Error()
 }
 
+// hasResources returns true if any of messages to generate is resource.
+func (g *generator) hasResources() bool {
+	for _, m := range g.order {
+		if g.modes[m] == modeResource {
+			return true
+		}
+	}
+	return false
+}
+
 // doMessage generate single message
 func (g *generator) genMessage(m *protogen.Message) error {
-	err := g.genGvk(m)
-	if err != nil {
-		return fmt.Errorf("unable to generate GVK for message '%s' : %w", m.GoIdent.GoName, err)
+	var err error
+	if g.modes[m] == modeResource {
+		err = g.genGvk(m)
+		if err != nil {
+			return fmt.Errorf("unable to generate GVK for message '%s' : %w", m.GoIdent.GoName, err)
+		}
 	}
 	g.deepCopyIntoMessage(m)
 	if g.sw.Error() != nil {
-		return fmt.Errorf("unable to generate DeepCopyInto function for message '%s' : %w", m.GoIdent.GoName, err)
+		return fmt.Errorf("unable to generate DeepCopyInto function for message '%s' : %w", m.GoIdent.GoName, g.sw.Error())
 	}
 	g.deepCopy(m)
 	if g.sw.Error() != nil {
-		return fmt.Errorf("unable to generate DeepCopy function for message '%s' : %w", m.GoIdent.GoName, err)
+		return fmt.Errorf("unable to generate DeepCopy function for message '%s' : %w", m.GoIdent.GoName, g.sw.Error())
+	}
+	if g.modes[m] != modeResource {
+		return nil
 	}
 	g.deepCopyObject(m)
 	if g.sw.Error() != nil {
-		return fmt.Errorf("unable to generate DeepCopyObject function for message '%s' : %w", m.GoIdent.GoName, err)
+		return fmt.Errorf("unable to generate DeepCopyObject function for message '%s' : %w", m.GoIdent.GoName, g.sw.Error())
+	}
+	err = g.genMetadata(m)
+	if err != nil {
+		return fmt.Errorf("unable to generate metadata accessors of message '%s' : %w", m.GoIdent.GoName, err)
+	}
+	err = g.genStatus(m)
+	if err != nil {
+		return fmt.Errorf("unable to generate spec and status accessors of message '%s' : %w", m.GoIdent.GoName, err)
+	}
+	err = g.genList(m)
+	if err != nil {
+		return fmt.Errorf("unable to generate list of message '%s' : %w", m.GoIdent.GoName, err)
+	}
+	if g.sw.Error() != nil {
+		return fmt.Errorf("unable to generate list of message '%s' : %w", m.GoIdent.GoName, g.sw.Error())
 	}
 	return nil
 }
 
 // newGenerator creates a new instance of generator from provided protogen file.
 // It'll collect all messages from file and construct order.
-func newGenerator(file *protogen.File) *generator {
+// genFile is used to qualify go identifiers from other packages.
+// All the messages from files to generate which are not skipped are first party, as deepcopy functions are generated
+// for them in this run.
+func newGenerator(params *Params, gen *protogen.Plugin, file *protogen.File, genFile *protogen.GeneratedFile) (*generator, error) {
+	var generated []*protogen.Message
+	for _, f := range gen.Files {
+		if !f.Generate {
+			continue
+		}
+		for _, m := range f.Messages {
+			collectMessages(m, &generated)
+		}
+	}
+
+	modes, err := resolveModes(params, generated)
+	if err != nil {
+		return nil, err
+	}
+
+	mapping, err := params.packageMapping().withFileOptions(file)
+	if err != nil {
+		return nil, err
+	}
+
+	accessors := params.gvkAccessors()
+	if err := accessors.validate(); err != nil {
+		return nil, err
+	}
+
+	firstPartyMessages := make(map[*protogen.Message]interface{}, len(generated))
+	for _, m := range generated {
+		if modes[m] != modeSkip {
+			firstPartyMessages[m] = new(interface{})
+		}
+	}
+
 	// collect all nested messages
-	var messages []*protogen.Message
+	var all []*protogen.Message
 	for _, m := range file.Messages {
-		collectMessages(m, &messages)
+		collectMessages(m, &all)
+	}
+
+	var messages []*protogen.Message
+	for _, m := range all {
+		if modes[m] != modeSkip {
+			messages = append(messages, m)
+		}
 	}
 
 	// sort to have exactly same order
@@ -117,24 +222,31 @@ func newGenerator(file *protogen.File) *generator {
 		return messages[i].GoIdent.GoName > messages[j].GoIdent.GoName
 	})
 
-	firstPartyMessages := make(map[*protogen.Message]interface{}, len(messages))
-	for _, m := range messages {
-		firstPartyMessages[m] = new(interface{})
-	}
-
 	return &generator{
 		firstPartyMessages: firstPartyMessages,
+		modes:              modes,
 		order:              messages,
+		genFile:            genFile,
 		sw: templates.NewSnippetWriter(bytes.NewBuffer([]byte{}), "{{", "}}", map[string]interface{}{
-			"GoType": GoType,
+			"GoType": func(f *protogen.Field) string {
+				return GoType(genFile, f)
+			},
+			"QualifiedGoIdent": genFile.QualifiedGoIdent,
 		}),
+		file:         file,
 		protoPackage: *file.Proto.Package,
+		mapping:      mapping,
 		goPackage:    string(file.GoPackageName),
-	}
+		accessors:    accessors,
+	}, nil
 }
 
-// collectMessages will recursively collect all proto messages
+// collectMessages will recursively collect all proto messages.
+// Map entries are skipped - there are no go types generated for them.
 func collectMessages(m *protogen.Message, all *[]*protogen.Message) {
+	if m.Desc.IsMapEntry() {
+		return
+	}
 	for _, subM := range m.Messages {
 		collectMessages(subM, all)
 	}
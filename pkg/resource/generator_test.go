@@ -2,12 +2,13 @@ package resource
 
 import (
 	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
-	"google.golang.org/protobuf/compiler/protogen"
+	gengo "google.golang.org/protobuf/cmd/protoc-gen-go/internal_gengo"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/testing/protocmp"
 	"google.golang.org/protobuf/types/pluginpb"
 	"gotest.tools/assert"
 	"os"
+	"os/exec"
 	"path/filepath"
 	"testing"
 )
@@ -19,12 +20,16 @@ func TestGenerate(t *testing.T) {
 	type args struct {
 		descriptorPath string
 		fileToGenerate string
+		// parameter is plugin parameter passed by protoc
+		parameter string
 	}
 	tests := []struct {
 		name         string
 		args         args
 		wantErr      bool
 		wantFilePath string
+		// wantRegisterPath is path to expected register file of go package, if any
+		wantRegisterPath string
 	}{
 		{
 			name: "Simple Types",
@@ -32,7 +37,8 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "simple.descriptor"),
 				fileToGenerate: "simple.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "simple.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "simple.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "simple.register.pb.go.etalone"),
 		},
 		{
 			name: "Optionals",
@@ -40,7 +46,8 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "optionals.descriptor"),
 				fileToGenerate: "optionals.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "optionals.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "optionals.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "optionals.register.pb.go.etalone"),
 		},
 		{
 			name: "Enums",
@@ -48,7 +55,8 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "enums.descriptor"),
 				fileToGenerate: "enums.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "enums.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "enums.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "enums.register.pb.go.etalone"),
 		},
 		{
 			name: "Messages",
@@ -56,7 +64,8 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "messages.descriptor"),
 				fileToGenerate: "messages.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "messages.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "messages.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "messages.register.pb.go.etalone"),
 		},
 		{
 			name: "Repeated Scalars",
@@ -64,7 +73,8 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "repeated_scalars.descriptor"),
 				fileToGenerate: "repeated_scalars.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "repeated_scalars.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "repeated_scalars.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "repeated_scalars.register.pb.go.etalone"),
 		},
 		{
 			name: "Repeated Enums",
@@ -72,7 +82,8 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "repeated_enums.descriptor"),
 				fileToGenerate: "repeated_enums.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "repeated_enums.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "repeated_enums.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "repeated_enums.register.pb.go.etalone"),
 		},
 		{
 			name: "Repeated Messages",
@@ -80,7 +91,165 @@ func TestGenerate(t *testing.T) {
 				descriptorPath: filepath.Join("testdata", "descriptors", "repeated_messages.descriptor"),
 				fileToGenerate: "repeated_messages.proto",
 			},
-			wantFilePath: filepath.Join("testdata", "etalons", "repeated_messages.pb.deepcopy.go.etalone"),
+			wantFilePath:     filepath.Join("testdata", "etalons", "repeated_messages.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "repeated_messages.register.pb.go.etalone"),
+		},
+		{
+			name: "Maps",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "maps.descriptor"),
+				fileToGenerate: "maps.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "maps.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "maps.register.pb.go.etalone"),
+		},
+		{
+			name: "OneOfs",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "oneofs.descriptor"),
+				fileToGenerate: "oneofs.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "oneofs.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "oneofs.register.pb.go.etalone"),
+		},
+		{
+			name: "Well Known Types",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "well_known.descriptor"),
+				fileToGenerate: "well_known.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "well_known.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "well_known.register.pb.go.etalone"),
+		},
+		{
+			name: "Recursive Well Known Types Only",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "well_known_struct.descriptor"),
+				fileToGenerate: "well_known_struct.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "well_known_struct.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "well_known_struct.register.pb.go.etalone"),
+		},
+		{
+			name: "Cross Package References",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "cross_package.descriptor"),
+				fileToGenerate: "cross_package.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "cross_package.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "cross_package.register.pb.go.etalone"),
+		},
+		{
+			name: "Proto2",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "proto2.descriptor"),
+				fileToGenerate: "proto2.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "proto2.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "proto2.register.pb.go.etalone"),
+		},
+		{
+			name: "Editions",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "editions.descriptor"),
+				fileToGenerate: "editions.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "editions.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "editions.register.pb.go.etalone"),
+		},
+		{
+			name: "Resource Options",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "options.descriptor"),
+				fileToGenerate: "options.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "options.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "options.register.pb.go.etalone"),
+		},
+		{
+			name: "File Defaults",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "file_defaults.descriptor"),
+				fileToGenerate: "file_defaults.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "file_defaults.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "file_defaults.register.pb.go.etalone"),
+		},
+		{
+			name: "Message Modes",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "modes.descriptor"),
+				fileToGenerate: "modes.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "modes.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "modes.register.pb.go.etalone"),
+		},
+		{
+			name: "Annotated Resources Only",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "annotated.descriptor"),
+				fileToGenerate: "annotated.proto",
+				parameter:      "resources=annotated",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "annotated.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "annotated.register.pb.go.etalone"),
+		},
+		{
+			name: "Package Mapping",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "package_mapping.descriptor"),
+				fileToGenerate: "package_mapping.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "package_mapping.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "package_mapping.register.pb.go.etalone"),
+		},
+		{
+			name: "Resource Names",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "names.descriptor"),
+				fileToGenerate: "names.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "names.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "names.register.pb.go.etalone"),
+		},
+		{
+			name: "Metadata",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "metadata.descriptor"),
+				fileToGenerate: "metadata.proto",
+				parameter:      "version_accessor=GetAPIVersion",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "metadata.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "metadata.register.pb.go.etalone"),
+		},
+		{
+			name: "Metadata Presence Mismatch",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "metadata_presence.descriptor"),
+				fileToGenerate: "metadata_presence.proto",
+				parameter:      "version_accessor=GetAPIVersion",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "metadata_presence.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "metadata_presence.register.pb.go.etalone"),
+		},
+		{
+			name: "Shipped Metadata",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "shipped_meta.descriptor"),
+				fileToGenerate: "shipped_meta.proto",
+				parameter:      "version_accessor=GetAPIVersion",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "shipped_meta.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "shipped_meta.register.pb.go.etalone"),
+		},
+		{
+			name: "Spec And Status",
+			args: args{
+				descriptorPath: filepath.Join("testdata", "descriptors", "spec_status.descriptor"),
+				fileToGenerate: "spec_status.proto",
+			},
+			wantFilePath:     filepath.Join("testdata", "etalons", "spec_status.pb.deepcopy.go.etalone"),
+			wantRegisterPath: filepath.Join("testdata", "etalons", "spec_status.register.pb.go.etalone"),
 		},
 	}
 	for _, tt := range tests {
@@ -91,21 +260,26 @@ func TestGenerate(t *testing.T) {
 
 			assert.NilError(t, err, "unable to create code generation request")
 			assert.Assert(t, req != nil, "codegeneration request is nil")
+			req.Parameter = proto.String(tt.args.parameter)
 
-			gen, err := protogen.Options{}.New(req)
+			params := &Params{}
+			gen, err := protoc.NewPlugin(req, params.Set)
 			assert.NilError(t, err, "unable to create protogen plugin")
-			gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
 
-			if err := Generate(gen, tt.args.fileToGenerate); (err != nil) != tt.wantErr {
+			if err := params.Generate(gen, tt.args.fileToGenerate); (err != nil) != tt.wantErr {
 				t.Errorf("Generate() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
 			gotResponse := gen.Response()
-			if len(gotResponse.File) > 0 {
-				gotResponse.File[0].Name = nil
+			for _, f := range gotResponse.File {
+				f.Name = nil
 			}
 
-			expectedResponse := loadResponse(t, tt.args.fileToGenerate, tt.wantFilePath)
+			files := []string{tt.args.fileToGenerate, tt.wantFilePath}
+			if tt.wantRegisterPath != "" {
+				files = append(files, registerFileName, tt.wantRegisterPath)
+			}
+			expectedResponse := loadResponse(t, files...)
 
 			assert.DeepEqual(t, expectedResponse, gotResponse, protocmp.Transform())
 		})
@@ -115,7 +289,9 @@ func TestGenerate(t *testing.T) {
 func loadResponse(t *testing.T, filesKV ...string) *pluginpb.CodeGeneratorResponse {
 	resp := &pluginpb.CodeGeneratorResponse{
 		Error:             nil,
-		SupportedFeatures: proto.Uint64(uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)),
+		SupportedFeatures: proto.Uint64(protoc.SupportedFeatures),
+		MinimumEdition:    proto.Int32(int32(protoc.MinimumEdition)),
+		MaximumEdition:    proto.Int32(int32(protoc.MaximumEdition)),
 		File:              nil,
 	}
 
@@ -137,3 +313,132 @@ func loadResponse(t *testing.T, filesKV ...string) *pluginpb.CodeGeneratorRespon
 
 	return resp
 }
+
+// TestGenerate_samePackage checks that several proto files of the same go package share single register file.
+func TestGenerate_samePackage(t *testing.T) {
+	tests := []struct {
+		name            string
+		descriptorPath  string
+		filesToGenerate []string
+		// wantFilesKV are pairs of expected file and path to its etalon in order of generation
+		wantFilesKV []string
+	}{
+		{
+			name:            "Several Files",
+			descriptorPath:  filepath.Join("testdata", "descriptors", "register.descriptor"),
+			filesToGenerate: []string{"register_a.proto", "register_b.proto"},
+			wantFilesKV: []string{
+				"register_a.proto", filepath.Join("testdata", "etalons", "register_a.pb.deepcopy.go.etalone"),
+				"register_b.proto", filepath.Join("testdata", "etalons", "register_b.pb.deepcopy.go.etalone"),
+				registerFileName, filepath.Join("testdata", "etalons", "register.register.pb.go.etalone"),
+			},
+		},
+		{
+			name:            "Leading File Without Messages",
+			descriptorPath:  filepath.Join("testdata", "descriptors", "register_leading.descriptor"),
+			filesToGenerate: []string{"register_enums.proto", "register_a.proto", "register_b.proto"},
+			wantFilesKV: []string{
+				registerFileName, filepath.Join("testdata", "etalons", "register.register.pb.go.etalone"),
+				"register_a.proto", filepath.Join("testdata", "etalons", "register_a.pb.deepcopy.go.etalone"),
+				"register_b.proto", filepath.Join("testdata", "etalons", "register_b.pb.deepcopy.go.etalone"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := protoc.ReadCodeGenerationRequest(tt.descriptorPath, tt.filesToGenerate...)
+			assert.NilError(t, err, "unable to create code generation request")
+
+			params := &Params{}
+			gen, err := protoc.NewPlugin(req, params.Set)
+			assert.NilError(t, err, "unable to create protogen plugin")
+
+			for _, file := range tt.filesToGenerate {
+				assert.NilError(t, params.Generate(gen, file))
+			}
+
+			gotResponse := gen.Response()
+			for _, f := range gotResponse.File {
+				f.Name = nil
+			}
+
+			expectedResponse := loadResponse(t, tt.wantFilesKV...)
+
+			assert.DeepEqual(t, expectedResponse, gotResponse, protocmp.Transform())
+		})
+	}
+}
+
+// TestGenerate_compiles checks that generated code compiles together with code generated by protoc-gen-go,
+// exact syntax doesn't catch unused or missing imports.
+func TestGenerate_compiles(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool is not available")
+	}
+
+	tests := []struct {
+		name           string
+		descriptorPath string
+		fileToGenerate string
+		parameter      string
+	}{
+		{
+			name:           "Well Known Types",
+			descriptorPath: filepath.Join("testdata", "descriptors", "well_known.descriptor"),
+			fileToGenerate: "well_known.proto",
+		},
+		{
+			name:           "Recursive Well Known Types Only",
+			descriptorPath: filepath.Join("testdata", "descriptors", "well_known_struct.descriptor"),
+			fileToGenerate: "well_known_struct.proto",
+		},
+		{
+			name:           "Metadata",
+			descriptorPath: filepath.Join("testdata", "descriptors", "metadata.descriptor"),
+			fileToGenerate: "metadata.proto",
+			parameter:      "version_accessor=GetAPIVersion",
+		},
+		{
+			name:           "Metadata Presence Mismatch",
+			descriptorPath: filepath.Join("testdata", "descriptors", "metadata_presence.descriptor"),
+			fileToGenerate: "metadata_presence.proto",
+			parameter:      "version_accessor=GetAPIVersion",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := protoc.ReadCodeGenerationRequest(tt.descriptorPath, tt.fileToGenerate)
+			assert.NilError(t, err, "unable to create code generation request")
+			req.Parameter = proto.String(tt.parameter)
+
+			params := &Params{}
+			gen, err := protoc.NewPlugin(req, params.Set)
+			assert.NilError(t, err, "unable to create protogen plugin")
+
+			for _, f := range gen.Files {
+				if f.Generate {
+					gengo.GenerateFile(gen, f)
+				}
+			}
+			assert.NilError(t, params.Generate(gen, tt.fileToGenerate))
+
+			resp := gen.Response()
+			assert.Assert(t, resp.Error == nil, "generation failed: %s", resp.GetError())
+
+			// package is built inside of the module to resolve its dependencies
+			dir, err := os.MkdirTemp("testdata", "compile")
+			assert.NilError(t, err)
+			defer os.RemoveAll(dir)
+
+			for _, f := range resp.File {
+				assert.NilError(t, os.WriteFile(filepath.Join(dir, filepath.Base(f.GetName())), []byte(f.GetContent()), 0o644))
+			}
+
+			out, err := exec.Command(goTool, "vet", "./"+filepath.ToSlash(dir)).CombinedOutput()
+			assert.NilError(t, err, "generated code doesn't compile:\n%s", out)
+		})
+	}
+}
@@ -4,47 +4,307 @@ import (
 	_ "embed"
 	"fmt"
 	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
 	"strings"
 )
 
 //go:embed templates/gvk.gotmpl
 var gvkTmpl string
 
+// objectKindPackage holds import path of package with ObjectKind implementation used by generated GetObjectKind.
+const objectKindPackage = protogen.GoImportPath("github.com/dgodyna/protoc-gen-resource/pkg/objectkind")
+
+// field numbers of google.protobuf.FileDescriptorProto used to find comments of file statements.
+const (
+	filePackageField = 2
+	fileSyntaxField  = 12
+)
+
 type gvk struct {
 	Group   string
 	Version string
 	Kind    string
 }
 
-// genGvk get group version & kind of resource from proto message and generate appropriate resource methods.
-func (g *generator) genGvk(m *protogen.Message) error {
-	// firstly try to load from comments
+// gvkAccessors holds names of generated methods returning group, version and kind of resource.
+type gvkAccessors struct {
+	Group   string
+	Version string
+	Kind    string
+}
+
+// validate checks that accessors don't clash with each other and with other generated methods of resource.
+func (a gvkAccessors) validate() error {
+	names := map[string]string{}
+	for _, accessor := range []struct {
+		name  string
+		param string
+	}{
+		{name: a.Group, param: "group_accessor"},
+		{name: a.Version, param: "version_accessor"},
+		{name: a.Kind, param: "kind_accessor"},
+	} {
+		if clash, ok := names[accessor.name]; ok {
+			return fmt.Errorf("parameters '%s' and '%s' configure the same accessor name '%s'", clash, accessor.param, accessor.name)
+		}
+		if contains(resourceMethods, accessor.name) {
+			return fmt.Errorf("accessor name '%s' configured by parameter '%s' clashes with generated method of resource",
+				accessor.name, accessor.param)
+		}
+		names[accessor.name] = accessor.param
+	}
+	return nil
+}
 
-	var res *gvk
-	var found bool
-	var err error
+// resourceMethods names of methods generated for each resource besides GVK accessors.
+var resourceMethods = []string{
+	"GetObjectKind", "GroupVersionKind", "DeepCopyInto", "DeepCopy", "DeepCopyObject",
+	"GetResourcePlural", "GetResourceSingular", "GetResourceShortNames", "GetResourceCategories", "GetResourceScope",
+	"GetSpec", "GetStatus", "SetStatus", "CopyStatusFrom",
+}
 
-	res, found, err = extractFromComments(m)
+// genGvk get group version & kind of resource from proto message and generate appropriate resource methods
+// followed by resource naming metadata.
+func (g *generator) genGvk(m *protogen.Message) error {
+	res, err := g.resolveGvk(m)
 	if err != nil {
 		return err
 	}
 
+	g.sw.Do(gvkTmpl, templates.Args{
+		"gvk":       res,
+		"accessors": g.accessors,
+		"type":      m.GoIdent.GoName,
+		"newStatic": g.genFile.QualifiedGoIdent(objectKindPackage.Ident("NewStatic")),
+	})
+
+	return g.genNames(m, res)
+}
+
+// resolveGvk get group version & kind of resource from the first source where it's configured:
+// 1) message option (protoc_gen_resource.resource)
+// 2) message comments. If both message option and comments are present - they must configure exactly the same GVK.
+// 3) file option (protoc_gen_resource.file_resource) or comments of file 'syntax' or 'package' statements
+// 4) protobuf package, see PackageMapping
+// If group and version are taken from file or package - kind still may be overridden by message option or comments,
+// otherwise message name is used as kind.
+func (g *generator) resolveGvk(m *protogen.Message) (*gvk, error) {
+	fromOptions, foundInOptions, err := extractFromOptions(m)
+	if err != nil {
+		return nil, err
+	}
+
+	fromComments, foundInComments, err := extractFromComments(m)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundInOptions && foundInComments && *fromOptions != *fromComments {
+		return nil, fmt.Errorf("GVK of message '%s' configured by option '(protoc_gen_resource.resource)' %+v "+
+			"disagrees with GVK configured by comments %+v", m.GoIdent.GoName, *fromOptions, *fromComments)
+	}
+
+	f := m.Desc.ParentFile()
+	messageAt := position(f, messagePath(m))
+	optionAt := position(f, messageOptionPath(m), messagePath(m))
+
+	if foundInOptions {
+		if err := validateGvk(m, fromOptions, optionAt, optionAt, optionAt); err != nil {
+			return nil, err
+		}
+		return fromOptions, nil
+	}
+	if foundInComments {
+		kindAt := messageAt
+		if _, found := extractMarker(m.Comments.Leading, "kind"); found {
+			kindAt = markerPosition(f, "kind", messagePath(m))
+		}
+		err := validateGvk(m, fromComments,
+			markerPosition(f, "group", messagePath(m)), markerPosition(f, "version", messagePath(m)), kindAt)
+		if err != nil {
+			return nil, err
+		}
+		return fromComments, nil
+	}
+
+	kind, err := extractKind(m)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupAt, versionAt string
+	res, found, err := extractFromFile(g.file)
+	if err != nil {
+		return nil, err
+	}
+	if found {
+		fileOptionAt := position(f, protoreflect.SourcePath{fileOptionsField, fileResourceField})
+		groupAt, versionAt = fileOptionAt, fileOptionAt
+		if fileOptions(g.file).GetGroup() == "" {
+			groupAt = markerPosition(f, "group", protoreflect.SourcePath{fileSyntaxField}, protoreflect.SourcePath{filePackageField})
+			versionAt = markerPosition(f, "version", protoreflect.SourcePath{fileSyntaxField}, protoreflect.SourcePath{filePackageField})
+		}
+	} else {
+		res, found = extractFromPackage(g.mapping, g.protoPackage, m)
+		groupAt = position(f, protoreflect.SourcePath{filePackageField})
+		versionAt = groupAt
+	}
 	if !found {
-		res, found = extractFromPackage(g.protoPackage, m)
-		if !found {
-			return fmt.Errorf("unable to generate GVK resource methods for message '%s' to generate them either add appropriate comments '+protoc-gen-resource:group=GROUP' "+
-				" '+protoc-gen-resource:version=VERSION', '+protoc-gen-resource:kind=KIND' or follow this package naming format <GROUP>.<VERSION> or "+
-				"<GROUP>.<VERSION>.[model|services] where <VERSION> must be 'hub' or follow v.* pattern", m.GoIdent.GoName)
+		return nil, fmt.Errorf("unable to generate GVK resource methods for message '%s' to generate them either add option '(protoc_gen_resource.resource)', "+
+			"appropriate comments '+protoc-gen-resource:group=GROUP' "+
+			" '+protoc-gen-resource:version=VERSION', '+protoc-gen-resource:kind=KIND', "+
+			"configure them for whole file by option '(protoc_gen_resource.file_resource)' or comments of 'syntax' or 'package' statement, "+
+			"or follow package naming format configured by package mapping rules, by default <GROUP>.<VERSION> or "+
+			"<GROUP>.<VERSION>.[model|services] where <VERSION> must be 'hub' or kubernetes version", m.GoIdent.GoName)
+	}
+
+	kindAt := messageAt
+	res.Kind = m.GoIdent.GoName
+	switch {
+	case messageOptions(m).GetKind() != "":
+		kindAt = optionAt
+		res.Kind = kind
+	case kind != "":
+		kindAt = markerPosition(f, "kind", messagePath(m))
+		res.Kind = kind
+	}
+
+	if err := validateGvk(m, res, groupAt, versionAt, kindAt); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// extractFromOptions will extract group version kind information from `(protoc_gen_resource.resource)` message option.
+// If kind is not specified - message name will be used.
+// If any of group or version specified without another one - error will be returned.
+func extractFromOptions(m *protogen.Message) (*gvk, bool, error) {
+	resource := messageOptions(m)
+	if resource == nil {
+		return nil, false, nil
+	}
+
+	if (resource.GetGroup() == "") != (resource.GetVersion() == "") {
+		return nil, false, fmt.Errorf("invalid configuration for GVK, both group and version of option "+
+			"'(protoc_gen_resource.resource)' must be provided for message '%s'", m.GoIdent.GoName)
+	}
+
+	if resource.GetGroup() == "" {
+		return nil, false, nil
+	}
+
+	kind := m.GoIdent.GoName
+	if resource.GetKind() != "" {
+		kind = resource.GetKind()
+	}
+
+	return &gvk{
+		Group:   resource.GetGroup(),
+		Version: resource.GetVersion(),
+		Kind:    kind,
+	}, true, nil
+}
+
+// extractKind will extract kind of message from `(protoc_gen_resource.resource)` message option or
+// +protoc-gen-resource:kind=KIND comment. It's used to override kind of resource if group and version are taken from
+// file or package.
+// If kind is not specified - empty string will be returned.
+// If both option and comment are present and disagree - error will be returned.
+func extractKind(m *protogen.Message) (string, error) {
+	fromOptions := messageOptions(m).GetKind()
+	fromComments, _ := extractMarker(m.Comments.Leading, "kind")
+
+	if fromOptions != "" && fromComments != "" && fromOptions != fromComments {
+		return "", fmt.Errorf("kind of message '%s' configured by option '(protoc_gen_resource.resource)' '%s' "+
+			"disagrees with kind configured by comments '%s'", m.GoIdent.GoName, fromOptions, fromComments)
+	}
+
+	if fromOptions != "" {
+		return fromOptions, nil
+	}
+
+	return fromComments, nil
+}
+
+// messageOptions returns `(protoc_gen_resource.resource)` option of message or nil if it's not set.
+func messageOptions(m *protogen.Message) *protoc_gen_resource.ResourceOptions {
+	opts, ok := m.Desc.Options().(*descriptorpb.MessageOptions)
+	if !ok || !proto.HasExtension(opts, protoc_gen_resource.E_Resource) {
+		return nil
+	}
+
+	return proto.GetExtension(opts, protoc_gen_resource.E_Resource).(*protoc_gen_resource.ResourceOptions)
+}
+
+// extractFromFile will extract default group and version of all file messages from `(protoc_gen_resource.file_resource)`
+// file option or from comments of file 'syntax' or 'package' statement:
+// Group must be specified by following comment: +protoc-gen-resource:group=GROUP
+// Version must be specified by following comment: +protoc-gen-resource:version=VERSION
+// Kind of returned GVK is always empty.
+// If any of group or version specified without another one or option disagrees with comments - error will be returned.
+func extractFromFile(f *protogen.File) (*gvk, bool, error) {
+	var fromOptions *gvk
+	if resource := fileOptions(f); resource != nil {
+		if (resource.GetGroup() == "") != (resource.GetVersion() == "") {
+			return nil, false, fmt.Errorf("invalid configuration for GVK, both group and version of option "+
+				"'(protoc_gen_resource.file_resource)' must be provided for file '%s'", f.Desc.Path())
+		}
+
+		if resource.GetGroup() != "" {
+			fromOptions = &gvk{
+				Group:   resource.GetGroup(),
+				Version: resource.GetVersion(),
+			}
 		}
 	}
 
-	g.sw.Do(gvkTmpl, templates.Args{
-		"gvk":  res,
-		"type": m.GoIdent.GoName,
-	})
+	// markers may be placed either on 'syntax' or on 'package' statement
+	locations := f.Desc.SourceLocations()
+	comments := protogen.Comments(locations.ByPath(protoreflect.SourcePath{fileSyntaxField}).LeadingComments +
+		"\n" + locations.ByPath(protoreflect.SourcePath{filePackageField}).LeadingComments)
 
-	return nil
+	group, groupFound := extractMarker(comments, "group")
+	version, versionFound := extractMarker(comments, "version")
+
+	if groupFound != versionFound {
+		return nil, false, fmt.Errorf("invalid configuration for GVK, both comments '+protoc-gen-resource:group=GROUP' "+
+			"and '+protoc-gen-resource:version=VERSION' must be provided for file '%s'", f.Desc.Path())
+	}
+
+	var fromComments *gvk
+	if groupFound {
+		fromComments = &gvk{
+			Group:   group,
+			Version: version,
+		}
+	}
+
+	switch {
+	case fromOptions != nil && fromComments != nil && *fromOptions != *fromComments:
+		return nil, false, fmt.Errorf("group and version of file '%s' configured by option '(protoc_gen_resource.file_resource)' %+v "+
+			"disagree with group and version configured by comments %+v", f.Desc.Path(), *fromOptions, *fromComments)
+	case fromOptions != nil:
+		return fromOptions, true, nil
+	case fromComments != nil:
+		return fromComments, true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// fileOptions returns `(protoc_gen_resource.file_resource)` option of file or nil if it's not set.
+func fileOptions(f *protogen.File) *protoc_gen_resource.FileResourceOptions {
+	opts, ok := f.Desc.Options().(*descriptorpb.FileOptions)
+	if !ok || !proto.HasExtension(opts, protoc_gen_resource.E_FileResource) {
+		return nil
+	}
+
+	return proto.GetExtension(opts, protoc_gen_resource.E_FileResource).(*protoc_gen_resource.FileResourceOptions)
 }
 
 // extractFromComments will extract group version kind information from protobuf message comments.
@@ -54,22 +314,10 @@ func (g *generator) genGvk(m *protogen.Message) error {
 // If kind is not specified - message name will be used.
 // If any of group or version specified without another one - error will be returned.
 func extractFromComments(m *protogen.Message) (*gvk, bool, error) {
+	group, groupFound := extractMarker(m.Comments.Leading, "group")
+	version, versionFound := extractMarker(m.Comments.Leading, "version")
 
-	groupFound := false
-	versionFound := false
-	kindFound := false
-
-	if strings.Contains(string(m.Comments.Leading), "+protoc-gen-resource:group=") {
-		groupFound = true
-	}
-	if strings.Contains(string(m.Comments.Leading), "+protoc-gen-resource:version=") {
-		versionFound = true
-	}
-	if strings.Contains(string(m.Comments.Leading), "+protoc-gen-resource:kind=") {
-		kindFound = true
-	}
-
-	if (groupFound != versionFound) && (groupFound || versionFound) {
+	if groupFound != versionFound {
 		return nil, false, fmt.Errorf("invalid configuration for GVK, both comments '+protoc-gen-resource:group=GROUP' "+
 			"and '+protoc-gen-resource:version=VERSION' must be provided for message '%s'", m.GoIdent.GoName)
 	}
@@ -78,20 +326,9 @@ func extractFromComments(m *protogen.Message) (*gvk, bool, error) {
 		return nil, false, nil
 	}
 
-	commentLine := string(m.Comments.Leading)
-	// comments are full of new lines and tabs - replace it with space
-	commentLine = strings.ReplaceAll(commentLine, "\n", " ")
-	commentLine = strings.ReplaceAll(commentLine, "\t", " ")
-	// add space to the end to have ability to split right part of comments by spaces
-	commentLine = commentLine + " "
-
-	// now let's extract group, version
-	group := strings.Split(strings.Split(commentLine, "+protoc-gen-resource:group=")[1], " ")[0]
-	version := strings.Split(strings.Split(commentLine, "+protoc-gen-resource:version=")[1], " ")[0]
-
 	kind := m.GoIdent.GoName
-	if kindFound {
-		kind = strings.Split(strings.Split(commentLine, "+protoc-gen-resource:kind=")[1], " ")[0]
+	if k, kindFound := extractMarker(m.Comments.Leading, "kind"); kindFound {
+		kind = k
 	}
 
 	return &gvk{
@@ -101,19 +338,57 @@ func extractFromComments(m *protogen.Message) (*gvk, bool, error) {
 	}, true, nil
 }
 
-// extractFromPackage will try to extract group, version, kind from protobuf package.
-// Package must match following patterns <GROUP>.<VERSION> or <GROUP>.<VERSION>.[services|model
-// Where version must be either 'hub' - for internal version or follow v.* pattern.
-// Group will be reversed:
-// com.mycompany.product1.api -> api.product1.mycompany.com
-// If package is not following patterns - return false.
-func extractFromPackage(p string, m *protogen.Message) (*gvk, bool) {
+// extractMarker will extract value of '+protoc-gen-resource:<name>=<value>' marker from comments.
+func extractMarker(comments protogen.Comments, name string) (string, bool) {
+	marker := "+protoc-gen-resource:" + name + "="
+
+	if !strings.Contains(string(comments), marker) {
+		return "", false
+	}
+
+	commentLine := string(comments)
+	// comments are full of new lines and tabs - replace it with space
+	commentLine = strings.ReplaceAll(commentLine, "\n", " ")
+	commentLine = strings.ReplaceAll(commentLine, "\t", " ")
+	// add space to the end to have ability to split right part of comments by spaces
+	commentLine = commentLine + " "
+
+	return strings.Split(strings.Split(commentLine, marker)[1], " ")[0], true
+}
+
+// extractLineMarker will extract value of '+protoc-gen-resource:<name>=<value>' marker from comments.
+// Unlike extractMarker value lasts till the end of line, so it may contain spaces.
+func extractLineMarker(comments protogen.Comments, name string) (string, bool) {
+	marker := "+protoc-gen-resource:" + name + "="
 
-	p = strings.TrimSuffix(p, ".model")
-	p = strings.TrimSuffix(p, ".services")
+	idx := strings.Index(string(comments), marker)
+	if idx < 0 {
+		return "", false
+	}
 
+	value := string(comments)[idx+len(marker):]
+	if end := strings.Index(value, "\n"); end >= 0 {
+		value = value[:end]
+	}
+
+	return strings.TrimSpace(value), true
+}
+
+// extractFromPackage will try to extract group, version, kind from protobuf package using mapping rules:
+// 1) trailing segments from StripSuffixes are removed
+// 2) last segment must match VersionRegex and it's used as version
+// 3) DropSegments are removed from the rest of segments
+// 4) segments are reversed if Reverse is set
+// 5) AddSegments are added to the beginning and GroupSuffix to the end of group
+// Message name is used as kind.
+// If package is not following the rules - return false.
+func extractFromPackage(pm PackageMapping, p string, m *protogen.Message) (*gvk, bool) {
 	packageParts := strings.Split(p, ".")
 
+	for len(packageParts) > 0 && contains(pm.StripSuffixes, packageParts[len(packageParts)-1]) {
+		packageParts = packageParts[:len(packageParts)-1]
+	}
+
 	if len(packageParts) < 2 {
 		// does not follow proposed format
 		return nil, false
@@ -121,17 +396,34 @@ func extractFromPackage(p string, m *protogen.Message) (*gvk, bool) {
 
 	version := packageParts[len(packageParts)-1]
 	// checks version
-	if version != "hub" && !strings.HasPrefix(version, "v") {
+	if !pm.VersionRegex.MatchString(version) {
 		return nil, false
 	}
 
-	// reverse package parts
-	for i, j := 0, len(packageParts)-1; i < j; i, j = i+1, j-1 {
-		packageParts[i], packageParts[j] = packageParts[j], packageParts[i]
+	var groupParts []string
+	for _, part := range packageParts[:len(packageParts)-1] {
+		if !contains(pm.DropSegments, part) {
+			groupParts = append(groupParts, part)
+		}
+	}
+
+	if pm.Reverse {
+		for i, j := 0, len(groupParts)-1; i < j; i, j = i+1, j-1 {
+			groupParts[i], groupParts[j] = groupParts[j], groupParts[i]
+		}
+	}
+
+	groupParts = append(append([]string{}, pm.AddSegments...), groupParts...)
+	if pm.GroupSuffix != "" {
+		groupParts = append(groupParts, pm.GroupSuffix)
+	}
+
+	if len(groupParts) == 0 {
+		return nil, false
 	}
 
 	return &gvk{
-		Group:   strings.Join(packageParts[1:], "."),
+		Group:   strings.Join(groupParts, "."),
 		Version: version,
 		Kind:    m.GoIdent.GoName,
 	}, true
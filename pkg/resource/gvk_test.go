@@ -1,9 +1,15 @@
 package resource
 
 import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
 	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
 	"google.golang.org/protobuf/types/descriptorpb"
+	"path/filepath"
 	"reflect"
+	"strings"
 	"testing"
 )
 
@@ -157,6 +163,8 @@ func Test_extractFromPackage(t *testing.T) {
 	type args struct {
 		packageStr string
 		m          *protogen.Message
+		// params are plugin parameters in <name>=<value> format configuring package mapping
+		params []string
 	}
 	tests := []struct {
 		name  string
@@ -236,6 +244,122 @@ func Test_extractFromPackage(t *testing.T) {
 			},
 			want1: true,
 		},
+		{
+			name: "version only starts with v",
+			args: args{
+				packageStr: "com.mycompany.vendor",
+			},
+		},
+		{
+			name: "legacy version regex",
+			args: args{
+				packageStr: "com.mycompany.vendor",
+				m: &protogen.Message{GoIdent: protogen.GoIdent{
+					GoName: "TestResource",
+				}},
+				params: []string{`package_version_regex=hub|v.*`},
+			},
+			want: &gvk{
+				Group:   "mycompany.com",
+				Version: "vendor",
+				Kind:    "TestResource",
+			},
+			want1: true,
+		},
+		{
+			name: "custom version regex",
+			args: args{
+				packageStr: "com.mycompany.api.release2",
+				m: &protogen.Message{GoIdent: protogen.GoIdent{
+					GoName: "TestResource",
+				}},
+				params: []string{`package_version_regex=release\d+`},
+			},
+			want: &gvk{
+				Group:   "api.mycompany.com",
+				Version: "release2",
+				Kind:    "TestResource",
+			},
+			want1: true,
+		},
+		{
+			name: "custom stripped suffixes",
+			args: args{
+				packageStr: "com.mycompany.api.v1.types.internal",
+				m: &protogen.Message{GoIdent: protogen.GoIdent{
+					GoName: "TestResource",
+				}},
+				params: []string{"package_strip_suffixes=types:internal"},
+			},
+			want: &gvk{
+				Group:   "api.mycompany.com",
+				Version: "v1",
+				Kind:    "TestResource",
+			},
+			want1: true,
+		},
+		{
+			name: "default suffixes are not stripped when overridden",
+			args: args{
+				packageStr: "com.mycompany.api.v1.model",
+				params:     []string{"package_strip_suffixes=types"},
+			},
+		},
+		{
+			name: "group suffix",
+			args: args{
+				packageStr: "acme.platform.widgets.v1",
+				m: &protogen.Message{GoIdent: protogen.GoIdent{
+					GoName: "TestResource",
+				}},
+				params: []string{"package_group_suffix=io"},
+			},
+			want: &gvk{
+				Group:   "widgets.platform.acme.io",
+				Version: "v1",
+				Kind:    "TestResource",
+			},
+			want1: true,
+		},
+		{
+			name: "no reverse",
+			args: args{
+				packageStr: "acme.platform.widgets.v1",
+				m: &protogen.Message{GoIdent: protogen.GoIdent{
+					GoName: "TestResource",
+				}},
+				params: []string{"package_reverse=false"},
+			},
+			want: &gvk{
+				Group:   "acme.platform.widgets",
+				Version: "v1",
+				Kind:    "TestResource",
+			},
+			want1: true,
+		},
+		{
+			name: "dropped and added segments",
+			args: args{
+				packageStr: "com.mycompany.api.widgets.v1",
+				m: &protogen.Message{GoIdent: protogen.GoIdent{
+					GoName: "TestResource",
+				}},
+				params: []string{"package_drop_segments=api:com", "package_add_segments=internal", "package_group_suffix=example.com"},
+			},
+			want: &gvk{
+				Group:   "internal.widgets.mycompany.example.com",
+				Version: "v1",
+				Kind:    "TestResource",
+			},
+			want1: true,
+		},
+		{
+			name: "all segments dropped",
+			args: args{
+				packageStr: "com.v1",
+				params:     []string{"package_drop_segments=com"},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -247,7 +371,15 @@ func Test_extractFromPackage(t *testing.T) {
 					Package: &tt.args.packageStr,
 				},
 			}
-			got, got1 := extractFromPackage(*f.Proto.Package, tt.args.m)
+			params := &Params{}
+			for _, param := range tt.args.params {
+				name, value, _ := strings.Cut(param, "=")
+				if err := params.Set(name, value); err != nil {
+					t.Fatalf("unable to set parameter '%s' : %v", param, err)
+				}
+			}
+
+			got, got1 := extractFromPackage(params.packageMapping(), *f.Proto.Package, tt.args.m)
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("extractFromPackage() got = %v, want %v", got, tt.want)
 			}
@@ -257,3 +389,322 @@ func Test_extractFromPackage(t *testing.T) {
 		})
 	}
 }
+
+// newOptionsFile creates protobuf file with single message 'GoName' in package 'test' with provided options.
+// File comments are attached to 'syntax' statement.
+func newOptionsFile(t *testing.T, fileOpts *protoc_gen_resource.FileResourceOptions, fileComments string, messageOpts *protoc_gen_resource.ResourceOptions, comments string) (*protogen.File, *protogen.Message) {
+	t.Helper()
+
+	fOpts := &descriptorpb.FileOptions{}
+	if fileOpts != nil {
+		proto.SetExtension(fOpts, protoc_gen_resource.E_FileResource, fileOpts)
+	}
+
+	mOpts := &descriptorpb.MessageOptions{}
+	if messageOpts != nil {
+		proto.SetExtension(mOpts, protoc_gen_resource.E_Resource, messageOpts)
+	}
+
+	fd, err := protodesc.NewFile(&descriptorpb.FileDescriptorProto{
+		Name:    proto.String("test.proto"),
+		Package: proto.String("test"),
+		Syntax:  proto.String("proto3"),
+		Options: fOpts,
+		MessageType: []*descriptorpb.DescriptorProto{{
+			Name:    proto.String("GoName"),
+			Options: mOpts,
+		}},
+		SourceCodeInfo: &descriptorpb.SourceCodeInfo{
+			Location: []*descriptorpb.SourceCodeInfo_Location{{
+				Path:            []int32{fileSyntaxField},
+				Span:            []int32{0, 0, 18},
+				LeadingComments: proto.String(fileComments),
+			}},
+		},
+	}, nil)
+	if err != nil {
+		t.Fatalf("unable to create file descriptor: %v", err)
+	}
+
+	return &protogen.File{Desc: fd}, &protogen.Message{
+		Desc:     fd.Messages().Get(0),
+		GoIdent:  protogen.GoIdent{GoName: "GoName"},
+		Comments: protogen.CommentSet{Leading: protogen.Comments(comments)},
+	}
+}
+
+func Test_extractFromOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *protoc_gen_resource.ResourceOptions
+		want    *gvk
+		want1   bool
+		wantErr bool
+	}{
+		{
+			name: "No option",
+		},
+		{
+			name: "Empty option",
+			opts: &protoc_gen_resource.ResourceOptions{},
+		},
+		{
+			name:    "Only group specified",
+			opts:    &protoc_gen_resource.ResourceOptions{Group: "TEST"},
+			wantErr: true,
+		},
+		{
+			name:    "Only version specified",
+			opts:    &protoc_gen_resource.ResourceOptions{Version: "TEST"},
+			wantErr: true,
+		},
+		{
+			name:    "Only kind specified",
+			opts:    &protoc_gen_resource.ResourceOptions{Kind: "TEST"},
+			wantErr: false,
+		},
+		{
+			name: "group, version, no kind",
+			opts: &protoc_gen_resource.ResourceOptions{Group: "TEST_option", Version: "TEST_option"},
+			want: &gvk{
+				Group:   "TEST_option",
+				Version: "TEST_option",
+				Kind:    "GoName",
+			},
+			want1: true,
+		},
+		{
+			name: "group, version, kind",
+			opts: &protoc_gen_resource.ResourceOptions{Group: "TEST_option", Version: "TEST_option", Kind: "TEST_option"},
+			want: &gvk{
+				Group:   "TEST_option",
+				Version: "TEST_option",
+				Kind:    "TEST_option",
+			},
+			want1: true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, m := newOptionsFile(t, nil, "", tt.opts, "")
+
+			got, got1, err := extractFromOptions(m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("extractFromOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("extractFromOptions() got = %v, want %v", got, tt.want)
+			}
+			if got1 != tt.want1 {
+				t.Errorf("extractFromOptions() got1 = %v, want %v", got1, tt.want1)
+			}
+		})
+	}
+}
+
+func Test_resolveGvk(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileOpts     *protoc_gen_resource.FileResourceOptions
+		fileComments string
+		messageOpts  *protoc_gen_resource.ResourceOptions
+		comments     string
+		want         *gvk
+		wantErr      bool
+	}{
+		{
+			name:    "Nothing configured",
+			wantErr: true,
+		},
+		{
+			name:        "Message option only",
+			messageOpts: &protoc_gen_resource.ResourceOptions{Group: "option.example.com", Version: "v1"},
+			want:        &gvk{Group: "option.example.com", Version: "v1", Kind: "GoName"},
+		},
+		{
+			name:     "Comments only",
+			comments: "+protoc-gen-resource:group=comment.example.com\n+protoc-gen-resource:version=v1\n",
+			want:     &gvk{Group: "comment.example.com", Version: "v1", Kind: "GoName"},
+		},
+		{
+			name:        "Message option and comments agree",
+			messageOpts: &protoc_gen_resource.ResourceOptions{Group: "option.example.com", Version: "v1", Kind: "Kind"},
+			comments:    "+protoc-gen-resource:group=option.example.com\n+protoc-gen-resource:version=v1\n+protoc-gen-resource:kind=Kind\n",
+			want:        &gvk{Group: "option.example.com", Version: "v1", Kind: "Kind"},
+		},
+		{
+			name:        "Message option and comments disagree",
+			messageOpts: &protoc_gen_resource.ResourceOptions{Group: "option.example.com", Version: "v1"},
+			comments:    "+protoc-gen-resource:group=comment.example.com\n+protoc-gen-resource:version=v1\n",
+			wantErr:     true,
+		},
+		{
+			name:     "File option",
+			fileOpts: &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			want:     &gvk{Group: "file.example.com", Version: "v1", Kind: "GoName"},
+		},
+		{
+			name:        "Message option overrides file option",
+			fileOpts:    &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			messageOpts: &protoc_gen_resource.ResourceOptions{Group: "option.example.com", Version: "v2"},
+			want:        &gvk{Group: "option.example.com", Version: "v2", Kind: "GoName"},
+		},
+		{
+			name:         "File comments",
+			fileComments: "+protoc-gen-resource:group=file.example.com\n+protoc-gen-resource:version=v1\n",
+			want:         &gvk{Group: "file.example.com", Version: "v1", Kind: "GoName"},
+		},
+		{
+			name:         "File option and comments agree",
+			fileOpts:     &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			fileComments: "+protoc-gen-resource:group=file.example.com\n+protoc-gen-resource:version=v1\n",
+			want:         &gvk{Group: "file.example.com", Version: "v1", Kind: "GoName"},
+		},
+		{
+			name:         "File option and comments disagree",
+			fileOpts:     &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			fileComments: "+protoc-gen-resource:group=file.example.com\n+protoc-gen-resource:version=v2\n",
+			wantErr:      true,
+		},
+		{
+			name:         "Invalid file comments",
+			fileComments: "+protoc-gen-resource:group=file.example.com\n",
+			wantErr:      true,
+		},
+		{
+			name:        "Kind overridden by message option",
+			fileOpts:    &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			messageOpts: &protoc_gen_resource.ResourceOptions{Kind: "Kind"},
+			want:        &gvk{Group: "file.example.com", Version: "v1", Kind: "Kind"},
+		},
+		{
+			name:         "Kind overridden by message comments",
+			fileComments: "+protoc-gen-resource:group=file.example.com\n+protoc-gen-resource:version=v1\n",
+			comments:     "+protoc-gen-resource:kind=Kind\n",
+			want:         &gvk{Group: "file.example.com", Version: "v1", Kind: "Kind"},
+		},
+		{
+			name:        "Kind overrides disagree",
+			fileOpts:    &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			messageOpts: &protoc_gen_resource.ResourceOptions{Kind: "Kind"},
+			comments:    "+protoc-gen-resource:kind=AnotherKind\n",
+			wantErr:     true,
+		},
+		{
+			name:     "Message comments override file option",
+			fileOpts: &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			comments: "+protoc-gen-resource:group=comment.example.com\n+protoc-gen-resource:version=v2\n",
+			want:     &gvk{Group: "comment.example.com", Version: "v2", Kind: "GoName"},
+		},
+		{
+			name:        "Invalid group",
+			messageOpts: &protoc_gen_resource.ResourceOptions{Group: "Foo_Bar", Version: "v1"},
+			wantErr:     true,
+		},
+		{
+			name:     "Invalid version",
+			comments: "+protoc-gen-resource:group=comment.example.com\n+protoc-gen-resource:version=V1\n",
+			wantErr:  true,
+		},
+		{
+			name:     "Version only starts with v",
+			fileOpts: &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "vendor"},
+			wantErr:  true,
+		},
+		{
+			name:     "Beta version",
+			fileOpts: &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v2beta3"},
+			want:     &gvk{Group: "file.example.com", Version: "v2beta3", Kind: "GoName"},
+		},
+		{
+			name:     "Hub version",
+			fileOpts: &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "hub"},
+			want:     &gvk{Group: "file.example.com", Version: "hub", Kind: "GoName"},
+		},
+		{
+			name:        "Invalid kind",
+			fileOpts:    &protoc_gen_resource.FileResourceOptions{Group: "file.example.com", Version: "v1"},
+			messageOpts: &protoc_gen_resource.ResourceOptions{Kind: "Go_Name"},
+			wantErr:     true,
+		},
+		{
+			name:     "Invalid file option",
+			fileOpts: &protoc_gen_resource.FileResourceOptions{Group: "file.example.com"},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			f, m := newOptionsFile(t, tt.fileOpts, tt.fileComments, tt.messageOpts, tt.comments)
+			g := &generator{file: f, protoPackage: "test", mapping: DefaultPackageMapping()}
+
+			got, err := g.resolveGvk(m)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveGvk() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveGvk() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_resolveGvk_position checks that validation errors point to the proto file and line of offending message or marker.
+func Test_resolveGvk_position(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "invalid_gvk.descriptor"), "invalid_gvk.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+	file := gen.FilesByPath["invalid_gvk.proto"]
+
+	tests := []struct {
+		message string
+		wantErr string
+	}{
+		{
+			message: "InvalidGroup",
+			wantErr: "invalid_gvk.proto:11: invalid group 'Foo_Bar'",
+		},
+		{
+			message: "InvalidVersion",
+			wantErr: "invalid_gvk.proto:17: invalid version 'V1'",
+		},
+		{
+			message: "InvalidKind",
+			wantErr: "invalid_gvk.proto:22: invalid kind 'invalid_kind'",
+		},
+		{
+			message: "InvalidKindOverride",
+			wantErr: "invalid_gvk.proto:29: invalid kind 'lowerCamelCase'",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.message, func(t *testing.T) {
+			var m *protogen.Message
+			for _, candidate := range file.Messages {
+				if candidate.GoIdent.GoName == tt.message {
+					m = candidate
+				}
+			}
+			if m == nil {
+				t.Fatalf("message '%s' not found", tt.message)
+			}
+
+			g := &generator{file: file, protoPackage: *file.Proto.Package, mapping: DefaultPackageMapping()}
+
+			_, err := g.resolveGvk(m)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("resolveGvk() error = %v, want prefix %v", err, tt.wantErr)
+			}
+		})
+	}
+}
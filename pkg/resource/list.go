@@ -0,0 +1,34 @@
+package resource
+
+import (
+	_ "embed"
+	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+//go:embed templates/list.gotmpl
+var listTmpl string
+
+// metav1Package holds import path of kubernetes meta package, used for ListMeta of generated lists.
+const metav1Package = protogen.GoImportPath("k8s.io/apimachinery/pkg/apis/meta/v1")
+
+// listSuffix is added to type and kind of resource to get type and kind of resource list.
+const listSuffix = "List"
+
+// genList generates <Type>List type of resource with ListMeta, Items, GetObjectKind and deepcopy functions.
+// Name clashes of generated list with protobuf definitions are checked together with register helpers.
+func (g *generator) genList(m *protogen.Message) error {
+	res, err := g.resolveGvk(m)
+	if err != nil {
+		return err
+	}
+
+	g.sw.Do(listTmpl, templates.Args{
+		"gvk":       res,
+		"type":      m.GoIdent.GoName,
+		"listMeta":  g.genFile.QualifiedGoIdent(metav1Package.Ident("ListMeta")),
+		"newStatic": g.genFile.QualifiedGoIdent(objectKindPackage.Ident("NewStatic")),
+	})
+
+	return nil
+}
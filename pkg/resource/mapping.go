@@ -0,0 +1,93 @@
+package resource
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/compiler/protogen"
+	"regexp"
+)
+
+// defaultVersionRegex matches kubernetes versions and internal 'hub' version.
+const defaultVersionRegex = `hub|v\d+((alpha|beta)\d+)?`
+
+// PackageMapping holds rules to get group and version from protobuf package.
+type PackageMapping struct {
+	// StripSuffixes trailing package segments removed before the version is taken.
+	StripSuffixes []string
+	// VersionRegex must match the whole last package segment to use it as version.
+	VersionRegex *regexp.Regexp
+	// Reverse package segments to get group, e.g. "com.mycompany.api" -> "api.mycompany.com".
+	Reverse bool
+	// DropSegments package segments removed from group.
+	DropSegments []string
+	// AddSegments segments added to the beginning of group.
+	AddSegments []string
+	// GroupSuffix suffix added to the end of group.
+	GroupSuffix string
+}
+
+// DefaultPackageMapping returns rules which are used if nothing is configured:
+// <GROUP>.<VERSION> or <GROUP>.<VERSION>.[model|services], where version is 'hub' or kubernetes version and group is reversed.
+func DefaultPackageMapping() PackageMapping {
+	return PackageMapping{
+		StripSuffixes: []string{"model", "services"},
+		VersionRegex:  mustCompileVersionRegex(defaultVersionRegex),
+		Reverse:       true,
+	}
+}
+
+// compileVersionRegex compiles version regex to match the whole package segment.
+func compileVersionRegex(expr string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + expr + `)$`)
+}
+
+func mustCompileVersionRegex(expr string) *regexp.Regexp {
+	r, err := compileVersionRegex(expr)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// withFileOptions returns copy of mapping with overrides from `(protoc_gen_resource.file_resource).package_mapping` file option.
+func (pm PackageMapping) withFileOptions(f *protogen.File) (PackageMapping, error) {
+	mapping := fileOptions(f).GetPackageMapping()
+	if mapping == nil {
+		return pm, nil
+	}
+
+	if len(mapping.GetStripSuffixes()) > 0 {
+		pm.StripSuffixes = mapping.GetStripSuffixes()
+	}
+	if mapping.GetVersionRegex() != "" {
+		r, err := compileVersionRegex(mapping.GetVersionRegex())
+		if err != nil {
+			return pm, fmt.Errorf("invalid 'version_regex' of option '(protoc_gen_resource.file_resource).package_mapping' "+
+				"for file '%s' : %w", f.Desc.Path(), err)
+		}
+		pm.VersionRegex = r
+	}
+	if mapping.Reverse != nil {
+		pm.Reverse = mapping.GetReverse()
+	}
+	if len(mapping.GetDropSegments()) > 0 {
+		pm.DropSegments = mapping.GetDropSegments()
+	}
+	if len(mapping.GetAddSegments()) > 0 {
+		pm.AddSegments = mapping.GetAddSegments()
+	}
+	if mapping.GetGroupSuffix() != "" {
+		pm.GroupSuffix = mapping.GetGroupSuffix()
+	}
+
+	return pm, nil
+}
+
+// contains returns true if value is one of values.
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,340 @@
+package resource
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+//go:embed templates/metadata.gotmpl
+var metadataTmpl string
+
+const (
+	// typesPackage holds import path of kubernetes types package, used for UID.
+	typesPackage = protogen.GoImportPath("k8s.io/apimachinery/pkg/types")
+	// timestamppbPackage holds import path of well-known Timestamp, used to set timestamps of metadata.
+	timestamppbPackage = protogen.GoImportPath("google.golang.org/protobuf/types/known/timestamppb")
+)
+
+// timestampName full name of well-known Timestamp message.
+const timestampName = "google.protobuf.Timestamp"
+
+// metaProtoPackage protobuf package of messages shipped in protoc_gen_resource/meta/v1/meta.proto.
+const metaProtoPackage = "protoc_gen_resource.meta.v1"
+
+// Full names of messages shipped in protoc_gen_resource/meta/v1/meta.proto, which are converted to metav1 types
+// by functions of their go package.
+const (
+	metaTimeName           = metaProtoPackage + ".Time"
+	metaOwnerReferenceName = metaProtoPackage + ".OwnerReference"
+)
+
+// Strategies of metav1.Object accessors delegating to field of metadata message.
+const (
+	// metaStrategyValue field has exactly the same go type as accessor.
+	metaStrategyValue = "value"
+	// metaStrategyUID string field converted to types.UID.
+	metaStrategyUID = "uid"
+	// metaStrategyOptional optional field is a pointer of the same go type as accessor.
+	metaStrategyOptional = "optional"
+	// metaStrategyOptionalValue optional field is a pointer to go type of accessor.
+	metaStrategyOptionalValue = "optionalValue"
+	// metaStrategyPointer field has go type pointed by accessor, zero value of the field stands for nil.
+	metaStrategyPointer = "pointer"
+	// metaStrategyTimestamp well-known Timestamp field converted to metav1.Time.
+	metaStrategyTimestamp = "timestamp"
+	// metaStrategyTimestampPtr well-known Timestamp field converted to *metav1.Time.
+	metaStrategyTimestampPtr = "timestampPtr"
+	// metaStrategyConvert field of type from meta package converted by its conversion functions.
+	metaStrategyConvert = "convert"
+	// metaStrategyMissing metadata message has no such field, getter returns zero value and setter ignores value.
+	metaStrategyMissing = "missing"
+)
+
+// goType is go type of metav1.Object accessor, either builtin or qualified identifier with optional '*' or '[]' prefix.
+type goType struct {
+	builtin string
+	prefix  string
+	ident   protogen.GoIdent
+}
+
+// builtinType returns go type which doesn't need qualification, e.g. "map[string]string".
+func builtinType(t string) goType {
+	return goType{builtin: t}
+}
+
+// qualifiedType returns go type of identifier from another package.
+func qualifiedType(prefix string, ident protogen.GoIdent) goType {
+	return goType{prefix: prefix, ident: ident}
+}
+
+// render returns go type qualified for generated file.
+func (t goType) render(genFile *protogen.GeneratedFile) string {
+	if t.builtin != "" {
+		return t.builtin
+	}
+	return t.prefix + genFile.QualifiedGoIdent(t.ident)
+}
+
+// zero returns zero value of go type rendered as provided string.
+func (t goType) zero(rendered string) string {
+	switch {
+	case t.builtin == "string" || t.ident.GoName == "UID":
+		return `""`
+	case t.builtin == "int64":
+		return "0"
+	case t.builtin == "" && t.prefix == "":
+		return rendered + "{}"
+	}
+	return "nil"
+}
+
+// metaField describes field of kubernetes ObjectMeta exposed by metav1.Object accessors.
+type metaField struct {
+	// Accessor is suffix of Get and Set methods of metav1.Object, e.g. "Name".
+	Accessor string
+	// ProtoName name of the field in metadata message, e.g. "name".
+	ProtoName string
+	// Description of the field used in comments of generated accessors.
+	Description string
+	// goType of accessor.
+	goType goType
+	// convertTo and convertFrom names of functions of meta package converting field of metaStrategyConvert.
+	convertTo, convertFrom string
+	// strategy returns how accessor is delegated to the field of metadata message or false if field type is not supported.
+	strategy func(field *protogen.Field) (string, bool)
+}
+
+// metaAccessor is metav1.Object accessor rendered by metadata template.
+type metaAccessor struct {
+	metaField
+	// Type qualified go type of accessor.
+	Type string
+	// Zero value of accessor type.
+	Zero string
+	// Field go name of the field in metadata message.
+	Field string
+	// Strategy how accessor is delegated to the field.
+	Strategy string
+	// ToFunc and FromFunc qualified functions converting field of metaStrategyConvert to and from type of accessor.
+	ToFunc, FromFunc string
+	// convertPackage go package of the field message with conversion functions.
+	convertPackage protogen.GoImportPath
+}
+
+var (
+	stringType = builtinType("string")
+	timeType   = qualifiedType("", metav1Package.Ident("Time"))
+)
+
+// metaFields all the fields of metav1.Object in order of the interface.
+var metaFields = []metaField{
+	{Accessor: "Namespace", ProtoName: "namespace", Description: "namespace", goType: stringType, strategy: stringStrategy(metaStrategyValue)},
+	{Accessor: "Name", ProtoName: "name", Description: "name", goType: stringType, strategy: stringStrategy(metaStrategyValue)},
+	{Accessor: "GenerateName", ProtoName: "generate_name", Description: "prefix of generated name", goType: stringType, strategy: stringStrategy(metaStrategyValue)},
+	{Accessor: "UID", ProtoName: "uid", Description: "unique identifier", goType: qualifiedType("", typesPackage.Ident("UID")), strategy: stringStrategy(metaStrategyUID)},
+	{Accessor: "ResourceVersion", ProtoName: "resource_version", Description: "resource version", goType: stringType, strategy: stringStrategy(metaStrategyValue)},
+	{Accessor: "Generation", ProtoName: "generation", Description: "generation", goType: builtinType("int64"), strategy: int64Strategy(false)},
+	{Accessor: "SelfLink", ProtoName: "self_link", Description: "self link", goType: stringType, strategy: stringStrategy(metaStrategyValue)},
+	{Accessor: "CreationTimestamp", ProtoName: "creation_timestamp", Description: "creation timestamp", goType: timeType, strategy: timestampStrategy(metaStrategyTimestamp), convertTo: "TimeToMetav1", convertFrom: "TimeFromMetav1"},
+	{Accessor: "DeletionTimestamp", ProtoName: "deletion_timestamp", Description: "deletion timestamp", goType: qualifiedType("*", timeType.ident), strategy: timestampStrategy(metaStrategyTimestampPtr), convertTo: "TimeToMetav1Ptr", convertFrom: "TimeFromMetav1Ptr"},
+	{Accessor: "DeletionGracePeriodSeconds", ProtoName: "deletion_grace_period_seconds", Description: "deletion grace period", goType: builtinType("*int64"), strategy: int64Strategy(true)},
+	{Accessor: "Labels", ProtoName: "labels", Description: "labels", goType: builtinType("map[string]string"), strategy: stringMapStrategy},
+	{Accessor: "Annotations", ProtoName: "annotations", Description: "annotations", goType: builtinType("map[string]string"), strategy: stringMapStrategy},
+	{Accessor: "Finalizers", ProtoName: "finalizers", Description: "finalizers", goType: builtinType("[]string"), strategy: stringListStrategy},
+	{Accessor: "OwnerReferences", ProtoName: "owner_references", Description: "owner references", goType: qualifiedType("[]", metav1Package.Ident("OwnerReference")), strategy: ownerReferencesStrategy, convertTo: "OwnerReferencesToMetav1", convertFrom: "OwnerReferencesFromMetav1"},
+	{Accessor: "ClusterName", ProtoName: "cluster_name", Description: "cluster name", goType: stringType, strategy: stringStrategy(metaStrategyValue)},
+	{Accessor: "ManagedFields", ProtoName: "managed_fields", Description: "managed fields", goType: qualifiedType("[]", metav1Package.Ident("ManagedFieldsEntry")), strategy: unsupportedStrategy},
+}
+
+// genMetadata generates metav1.Object accessors of resource delegating to its metadata field if there is any.
+func (g *generator) genMetadata(m *protogen.Message) error {
+	meta, err := roleField(m, fieldRoleMetadata)
+	if err != nil || meta == nil {
+		return err
+	}
+
+	accessors, err := metaAccessors(meta.Message)
+	if err != nil {
+		return err
+	}
+
+	if err := checkMetadataClashes(m, g.accessors); err != nil {
+		return err
+	}
+
+	args := templates.Args{
+		"type":     m.GoIdent.GoName,
+		"meta":     meta.GoName,
+		"metaType": g.genFile.QualifiedGoIdent(meta.Message.GoIdent),
+		"object":   g.genFile.QualifiedGoIdent(metav1Package.Ident("Object")),
+	}
+	for i, a := range accessors {
+		accessors[i].Type = a.goType.render(g.genFile)
+		accessors[i].Zero = a.goType.zero(accessors[i].Type)
+		if a.Strategy == metaStrategyConvert {
+			accessors[i].ToFunc = g.genFile.QualifiedGoIdent(a.convertPackage.Ident(a.convertTo))
+			accessors[i].FromFunc = g.genFile.QualifiedGoIdent(a.convertPackage.Ident(a.convertFrom))
+		}
+		if a.Strategy == metaStrategyTimestamp || a.Strategy == metaStrategyTimestampPtr {
+			args["newTime"] = g.genFile.QualifiedGoIdent(metav1Package.Ident("NewTime"))
+			args["newTimestamp"] = g.genFile.QualifiedGoIdent(timestamppbPackage.Ident("New"))
+		}
+	}
+	args["accessors"] = accessors
+
+	g.sw.Do(metadataTmpl, args)
+
+	return nil
+}
+
+// metaAccessors resolves how each metav1.Object accessor is delegated to the field of metadata message.
+// Fields are matched by name, fields which are absent in metadata message have metaStrategyMissing.
+// If field is present but has type which can't be converted to the type of accessor - error is returned.
+func metaAccessors(meta *protogen.Message) ([]metaAccessor, error) {
+	accessors := make([]metaAccessor, 0, len(metaFields))
+	for _, mf := range metaFields {
+		a := metaAccessor{metaField: mf, Strategy: metaStrategyMissing}
+
+		for _, field := range meta.Fields {
+			if string(field.Desc.Name()) != mf.ProtoName {
+				continue
+			}
+
+			strategy, ok := mf.strategy(field)
+			if !ok {
+				f := meta.Desc.ParentFile()
+				return nil, fmt.Errorf("%s: field '%s' of metadata message '%s' has type which can't be used as %s of metav1.Object",
+					position(f, f.SourceLocations().ByDescriptor(field.Desc).Path), mf.ProtoName, meta.GoIdent.GoName, mf.Description)
+			}
+			a.Field = field.GoName
+			a.Strategy = strategy
+			if field.Message != nil {
+				a.convertPackage = field.Message.GoIdent.GoImportPath
+			}
+		}
+
+		accessors = append(accessors, a)
+	}
+
+	return accessors, nil
+}
+
+// checkMetadataClashes returns error if any of metav1.Object accessors clashes with getters generated by protoc-gen-go
+// for fields of resource or with accessors of resource GVK.
+func checkMetadataClashes(m *protogen.Message, accessors gvkAccessors) error {
+	methods := make(map[string]string)
+	for _, mf := range metaFields {
+		methods["Get"+mf.Accessor] = mf.Accessor
+		methods["Set"+mf.Accessor] = mf.Accessor
+	}
+
+	f := m.Desc.ParentFile()
+	for _, field := range m.Fields {
+		if _, ok := methods["Get"+field.GoName]; ok {
+			return fmt.Errorf("%s: getter 'Get%s' of field '%s' clashes with metav1.Object accessor of message '%s', "+
+				"rename the field", position(f, f.SourceLocations().ByDescriptor(field.Desc).Path), field.GoName,
+				field.Desc.Name(), m.GoIdent.GoName)
+		}
+	}
+	for _, o := range m.Oneofs {
+		if _, ok := methods["Get"+o.GoName]; ok {
+			return fmt.Errorf("%s: getter 'Get%s' of oneof '%s' clashes with metav1.Object accessor of message '%s', "+
+				"rename the oneof", position(f, f.SourceLocations().ByDescriptor(o.Desc).Path), o.GoName,
+				o.Desc.Name(), m.GoIdent.GoName)
+		}
+	}
+
+	for _, a := range []struct {
+		name  string
+		param string
+	}{
+		{name: accessors.Group, param: "group_accessor"},
+		{name: accessors.Version, param: "version_accessor"},
+		{name: accessors.Kind, param: "kind_accessor"},
+	} {
+		if _, ok := methods[a.name]; ok {
+			return fmt.Errorf("%s: GVK accessor '%s' of message '%s' clashes with metav1.Object accessor, "+
+				"configure another name by plugin parameter '%s'", position(f, messagePath(m)), a.name, m.GoIdent.GoName, a.param)
+		}
+	}
+
+	return nil
+}
+
+// stringStrategy returns strategy function accepting singular string fields without presence.
+func stringStrategy(strategy string) func(field *protogen.Field) (string, bool) {
+	return func(field *protogen.Field) (string, bool) {
+		return strategy, isSingular(field) && !field.Desc.HasPresence() && field.Desc.Kind() == protoreflect.StringKind
+	}
+}
+
+// int64Strategy returns strategy function accepting singular int64 fields for accessor of int64 or *int64 go type.
+// Fields with presence are pointers, so they are dereferenced for int64 accessor and fields without presence
+// are referenced for *int64 one.
+func int64Strategy(pointer bool) func(field *protogen.Field) (string, bool) {
+	return func(field *protogen.Field) (string, bool) {
+		if !isSingular(field) || !isInt64(field.Desc) {
+			return "", false
+		}
+		switch {
+		case field.Desc.HasPresence() && pointer:
+			return metaStrategyOptional, true
+		case field.Desc.HasPresence():
+			return metaStrategyOptionalValue, true
+		case pointer:
+			return metaStrategyPointer, true
+		}
+		return metaStrategyValue, true
+	}
+}
+
+// timestampStrategy returns strategy function accepting singular well-known Timestamp fields
+// and Time fields from meta package.
+func timestampStrategy(strategy string) func(field *protogen.Field) (string, bool) {
+	return func(field *protogen.Field) (string, bool) {
+		if !isSingular(field) || field.Message == nil {
+			return "", false
+		}
+		switch field.Message.Desc.FullName() {
+		case timestampName:
+			return strategy, true
+		case metaTimeName:
+			return metaStrategyConvert, true
+		}
+		return "", false
+	}
+}
+
+// ownerReferencesStrategy accepts repeated OwnerReference fields from meta package.
+func ownerReferencesStrategy(field *protogen.Field) (string, bool) {
+	return metaStrategyConvert, field.Desc.IsList() && field.Message != nil &&
+		field.Message.Desc.FullName() == metaOwnerReferenceName
+}
+
+// stringMapStrategy accepts map<string, string> fields.
+func stringMapStrategy(field *protogen.Field) (string, bool) {
+	return metaStrategyValue, field.Desc.IsMap() &&
+		field.Desc.MapKey().Kind() == protoreflect.StringKind && field.Desc.MapValue().Kind() == protoreflect.StringKind
+}
+
+// stringListStrategy accepts repeated string fields.
+func stringListStrategy(field *protogen.Field) (string, bool) {
+	return metaStrategyValue, field.Desc.IsList() && field.Desc.Kind() == protoreflect.StringKind
+}
+
+// unsupportedStrategy field must be absent in metadata message.
+func unsupportedStrategy(*protogen.Field) (string, bool) {
+	return "", false
+}
+
+// isInt64 returns true if field go type is int64.
+func isInt64(field protoreflect.FieldDescriptor) bool {
+	switch field.Kind() {
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return true
+	}
+	return false
+}
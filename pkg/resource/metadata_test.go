@@ -0,0 +1,105 @@
+package resource
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	"google.golang.org/protobuf/compiler/protogen"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_genMetadata(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "invalid_metadata.descriptor"), "invalid_metadata.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+	file := gen.FilesByPath["invalid_metadata.proto"]
+
+	tests := []struct {
+		message   string
+		accessors gvkAccessors
+		wantErr   string
+	}{
+		{
+			message: "TwoMetadataFields",
+			wantErr: "invalid_metadata.proto:21: message 'TwoMetadataFields' has more than one metadata field: 'metadata' and 'other'",
+		},
+		{
+			message: "RepeatedMetadata",
+			wantErr: "invalid_metadata.proto:26: metadata field 'metadata' of message 'RepeatedMetadata' must be singular message field",
+		},
+		{
+			message: "InvalidRole",
+			wantErr: "invalid_metadata.proto:30: invalid comment '+protoc-gen-resource:field=labels'",
+		},
+		{
+			message: "InvalidMetaType",
+			wantErr: "invalid_metadata.proto:14: field 'name' of metadata message 'InvalidMeta' has type which can't be used as name",
+		},
+		{
+			message:   "GetterClash",
+			accessors: (&Params{VersionAccessor: "GetAPIVersion"}).gvkAccessors(),
+			wantErr:   "invalid_metadata.proto:42: getter 'GetName' of field 'name' clashes with metav1.Object accessor",
+		},
+		{
+			message:   "ObjectMeta",
+			accessors: (&Params{}).gvkAccessors(),
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.message, func(t *testing.T) {
+			var m *protogen.Message
+			for _, candidate := range file.Messages {
+				if candidate.GoIdent.GoName == tt.message {
+					m = candidate
+				}
+			}
+
+			genFile := gen.NewGeneratedFile("test.go", file.GoImportPath)
+			g, err := newGenerator(&Params{}, gen, file, genFile)
+			if err != nil {
+				t.Fatalf("unable to create generator: %v", err)
+			}
+			g.accessors = tt.accessors
+
+			err = g.genMetadata(m)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("genMetadata() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("genMetadata() error = %v, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_checkMetadataClashes(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "metadata.descriptor"), "metadata.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+	widget := gen.FilesByPath["metadata.proto"].Messages[1]
+
+	err = checkMetadataClashes(widget, (&Params{}).gvkAccessors())
+	wantErr := "metadata.proto:25: GVK accessor 'GetResourceVersion' of message 'Widget' clashes with metav1.Object accessor, " +
+		"configure another name by plugin parameter 'version_accessor'"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("checkMetadataClashes() error = %v, want %q", err, wantErr)
+	}
+
+	if err := checkMetadataClashes(widget, (&Params{VersionAccessor: "GetAPIVersion"}).gvkAccessors()); err != nil {
+		t.Errorf("checkMetadataClashes() unexpected error = %v", err)
+	}
+}
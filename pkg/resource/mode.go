@@ -0,0 +1,167 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// messageMode defines which methods are generated for the message.
+type messageMode int
+
+const (
+	// modeUnknown mode is not resolved yet.
+	modeUnknown messageMode = iota
+	// modeResource GVK methods, DeepCopyInto, DeepCopy and DeepCopyObject are generated.
+	modeResource
+	// modeDeepCopy only DeepCopyInto and DeepCopy are generated.
+	modeDeepCopy
+	// modeSkip nothing is generated.
+	modeSkip
+)
+
+// resolveModes resolves mode of each provided message:
+// 1) mode configured for message by option `(protoc_gen_resource.resource).mode` or comment `+protoc-gen-resource:mode=MODE`
+// 2) default mode of message defined by plugin parameter `resources`, see defaultMode
+// 3) deepcopy for messages referenced by resources or deepcopy messages
+// 4) skip for the rest of messages
+func resolveModes(params *Params, messages []*protogen.Message) (map[*protogen.Message]messageMode, error) {
+	modes := make(map[*protogen.Message]messageMode, len(messages))
+
+	var queue []*protogen.Message
+	for _, m := range messages {
+		mode, err := explicitMode(m)
+		if err != nil {
+			return nil, err
+		}
+
+		if mode == modeUnknown {
+			mode = defaultMode(params, m)
+		}
+
+		modes[m] = mode
+		if mode == modeResource || mode == modeDeepCopy {
+			queue = append(queue, m)
+		}
+	}
+
+	// all the messages referenced by generated ones must have deepcopy as well
+	for len(queue) > 0 {
+		m := queue[0]
+		queue = queue[1:]
+
+		for _, ref := range referencedMessages(m) {
+			if mode, ok := modes[ref]; ok && mode == modeUnknown {
+				modes[ref] = modeDeepCopy
+				queue = append(queue, ref)
+			}
+		}
+	}
+
+	for m, mode := range modes {
+		if mode == modeUnknown {
+			modes[m] = modeSkip
+		}
+	}
+
+	return modes, nil
+}
+
+// defaultMode returns mode of message without explicitly configured one:
+// 1) `resources=all` - resource for top level messages and deepcopy for nested ones. Nested messages are helper types
+// of their parents, and their go names like `Outer_Inner` are not valid kinds anyway
+// 2) `resources=annotated` - resource for top level messages with group, version or kind configured by option or comments
+// If message doesn't get a mode by default - modeUnknown is returned.
+func defaultMode(params *Params, m *protogen.Message) messageMode {
+	switch {
+	case !isTopLevel(m):
+		if params.annotatedOnly() {
+			return modeUnknown
+		}
+		return modeDeepCopy
+	case params.annotatedOnly() && !isAnnotated(m):
+		return modeUnknown
+	default:
+		return modeResource
+	}
+}
+
+// explicitMode returns mode configured for message by option `(protoc_gen_resource.resource).mode` or
+// comment `+protoc-gen-resource:mode=[resource|deepcopy|skip]`.
+// If mode is not configured - modeUnknown is returned.
+// If option and comment disagree - error is returned.
+func explicitMode(m *protogen.Message) (messageMode, error) {
+	fromOptions := modeUnknown
+	switch messageOptions(m).GetMode() {
+	case protoc_gen_resource.Mode_MODE_RESOURCE:
+		fromOptions = modeResource
+	case protoc_gen_resource.Mode_MODE_DEEPCOPY:
+		fromOptions = modeDeepCopy
+	case protoc_gen_resource.Mode_MODE_SKIP:
+		fromOptions = modeSkip
+	}
+
+	fromComments := modeUnknown
+	if value, found := extractMarker(m.Comments.Leading, "mode"); found {
+		switch value {
+		case "resource":
+			fromComments = modeResource
+		case "deepcopy":
+			fromComments = modeDeepCopy
+		case "skip":
+			fromComments = modeSkip
+		default:
+			return modeUnknown, fmt.Errorf("invalid comment '+protoc-gen-resource:mode=%s' of message '%s', "+
+				"mode must be one of 'resource', 'deepcopy', 'skip'", value, m.GoIdent.GoName)
+		}
+	}
+
+	if fromOptions != modeUnknown && fromComments != modeUnknown && fromOptions != fromComments {
+		return modeUnknown, fmt.Errorf("mode of message '%s' configured by option '(protoc_gen_resource.resource)' "+
+			"disagrees with mode configured by comments", m.GoIdent.GoName)
+	}
+
+	if fromOptions != modeUnknown {
+		return fromOptions, nil
+	}
+
+	return fromComments, nil
+}
+
+// isAnnotated returns true if group, version or kind is configured for message by option or comments.
+func isAnnotated(m *protogen.Message) bool {
+	opts := messageOptions(m)
+	if opts.GetGroup() != "" || opts.GetVersion() != "" || opts.GetKind() != "" {
+		return true
+	}
+
+	for _, marker := range []string{"group", "version", "kind"} {
+		if _, found := extractMarker(m.Comments.Leading, marker); found {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isTopLevel returns true if message is not nested into another message.
+func isTopLevel(m *protogen.Message) bool {
+	_, ok := m.Desc.Parent().(protoreflect.FileDescriptor)
+	return ok
+}
+
+// referencedMessages returns all the messages referenced by message fields including values of maps.
+func referencedMessages(m *protogen.Message) []*protogen.Message {
+	var res []*protogen.Message
+	for _, field := range m.Fields {
+		if field.Desc.IsMap() {
+			field = field.Message.Fields[1]
+		}
+		if field.Message != nil {
+			res = append(res, field.Message)
+		}
+	}
+
+	return res
+}
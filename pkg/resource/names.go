@@ -0,0 +1,155 @@
+package resource
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
+	"google.golang.org/protobuf/compiler/protogen"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"strings"
+)
+
+//go:embed templates/names.gotmpl
+var namesTmpl string
+
+const (
+	// scopeNamespaced resource is namespaced.
+	scopeNamespaced = "Namespaced"
+	// scopeCluster resource is cluster scoped.
+	scopeCluster = "Cluster"
+)
+
+// resourceNames holds naming metadata of resource.
+type resourceNames struct {
+	Plural     string
+	Singular   string
+	ShortNames []string
+	Categories []string
+	Scope      string
+}
+
+// genNames get naming metadata of resource from proto message and generate appropriate constants and methods.
+func (g *generator) genNames(m *protogen.Message, res *gvk) error {
+	names, err := resolveNames(m, res)
+	if err != nil {
+		return err
+	}
+
+	g.sw.Do(namesTmpl, templates.Args{
+		"names": names,
+		"type":  m.GoIdent.GoName,
+	})
+
+	return nil
+}
+
+// resolveNames get naming metadata of resource from `(protoc_gen_resource.resource)` message option or comments:
+// +protoc-gen-resource:plural=PLURAL, by default guessed from kind, e.g. "Widget" -> "widgets"
+// +protoc-gen-resource:singular=SINGULAR, by default lowercase kind
+// +protoc-gen-resource:short-names=NAME1,NAME2
+// +protoc-gen-resource:categories=CATEGORY1,CATEGORY2
+// +protoc-gen-resource:scope=[Namespaced|Cluster], by default Namespaced
+// If both option and comment are present and disagree - error will be returned.
+func resolveNames(m *protogen.Message, res *gvk) (*resourceNames, error) {
+	opts := messageOptions(m)
+
+	plural, singular := meta.UnsafeGuessKindToResource(schema.GroupVersionKind{Group: res.Group, Version: res.Version, Kind: res.Kind})
+	names := &resourceNames{
+		Plural:   plural.Resource,
+		Singular: singular.Resource,
+		Scope:    scopeNamespaced,
+	}
+
+	var optionScope string
+	switch opts.GetScope() {
+	case protoc_gen_resource.Scope_SCOPE_NAMESPACED:
+		optionScope = scopeNamespaced
+	case protoc_gen_resource.Scope_SCOPE_CLUSTER:
+		optionScope = scopeCluster
+	}
+
+	settings := []struct {
+		marker     string
+		fromOption string
+		value      *string
+		validate   func(string) []string
+	}{
+		{marker: "plural", fromOption: opts.GetPlural(), value: &names.Plural, validate: validation.IsDNS1035Label},
+		{marker: "singular", fromOption: opts.GetSingular(), value: &names.Singular, validate: validation.IsDNS1035Label},
+		{marker: "scope", fromOption: optionScope, value: &names.Scope, validate: validateScope},
+	}
+	for _, s := range settings {
+		value, at, found, err := resolveSetting(m, s.marker, s.fromOption)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		if errs := s.validate(value); len(errs) > 0 {
+			return nil, fmt.Errorf("%s: invalid %s '%s' of message '%s': %s",
+				at, s.marker, value, m.GoIdent.GoName, strings.Join(errs, "; "))
+		}
+		*s.value = value
+	}
+
+	lists := []struct {
+		marker     string
+		fromOption []string
+		value      *[]string
+	}{
+		{marker: "short-names", fromOption: opts.GetShortNames(), value: &names.ShortNames},
+		{marker: "categories", fromOption: opts.GetCategories(), value: &names.Categories},
+	}
+	for _, l := range lists {
+		value, at, found, err := resolveSetting(m, l.marker, strings.Join(l.fromOption, ","))
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			continue
+		}
+		for _, v := range strings.Split(value, ",") {
+			if errs := validation.IsDNS1035Label(v); len(errs) > 0 {
+				return nil, fmt.Errorf("%s: invalid %s '%s' of message '%s': %s",
+					at, l.marker, v, m.GoIdent.GoName, strings.Join(errs, "; "))
+			}
+			*l.value = append(*l.value, v)
+		}
+	}
+
+	return names, nil
+}
+
+// resolveSetting returns value of message setting configured either by option or by '+protoc-gen-resource:<marker>=' comment
+// and position of the value in proto file.
+// If both option and comment are present and disagree - error will be returned.
+func resolveSetting(m *protogen.Message, marker, fromOption string) (string, string, bool, error) {
+	f := m.Desc.ParentFile()
+	fromComments, foundInComments := extractMarker(m.Comments.Leading, marker)
+
+	if fromOption != "" && foundInComments && fromOption != fromComments {
+		return "", "", false, fmt.Errorf("%s of message '%s' configured by option '(protoc_gen_resource.resource)' '%s' "+
+			"disagrees with %s configured by comments '%s'", marker, m.GoIdent.GoName, fromOption, marker, fromComments)
+	}
+
+	if fromOption != "" {
+		return fromOption, position(f, messageOptionPath(m), messagePath(m)), true, nil
+	}
+	if foundInComments {
+		return fromComments, markerPosition(f, marker, messagePath(m)), true, nil
+	}
+
+	return "", "", false, nil
+}
+
+// validateScope checks that scope is either Namespaced or Cluster.
+func validateScope(scope string) []string {
+	if scope != scopeNamespaced && scope != scopeCluster {
+		return []string{fmt.Sprintf("must be either '%s' or '%s'", scopeNamespaced, scopeCluster)}
+	}
+	return nil
+}
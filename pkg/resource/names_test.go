@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
+	"reflect"
+	"testing"
+)
+
+func Test_resolveNames(t *testing.T) {
+	tests := []struct {
+		name     string
+		kind     string
+		opts     *protoc_gen_resource.ResourceOptions
+		comments string
+		want     *resourceNames
+		wantErr  bool
+	}{
+		{
+			name: "Defaults",
+			kind: "Widget",
+			want: &resourceNames{Plural: "widgets", Singular: "widget", Scope: scopeNamespaced},
+		},
+		{
+			name: "Pluralize y",
+			kind: "Policy",
+			want: &resourceNames{Plural: "policies", Singular: "policy", Scope: scopeNamespaced},
+		},
+		{
+			name: "Pluralize s",
+			kind: "Status",
+			want: &resourceNames{Plural: "statuses", Singular: "status", Scope: scopeNamespaced},
+		},
+		{
+			name: "Options",
+			kind: "Widget",
+			opts: &protoc_gen_resource.ResourceOptions{
+				Plural:     "widgetry",
+				Singular:   "wdgt",
+				ShortNames: []string{"wd", "wdg"},
+				Categories: []string{"all"},
+				Scope:      protoc_gen_resource.Scope_SCOPE_CLUSTER,
+			},
+			want: &resourceNames{
+				Plural:     "widgetry",
+				Singular:   "wdgt",
+				ShortNames: []string{"wd", "wdg"},
+				Categories: []string{"all"},
+				Scope:      scopeCluster,
+			},
+		},
+		{
+			name: "Comments",
+			kind: "Widget",
+			comments: "+protoc-gen-resource:plural=widgetry\n+protoc-gen-resource:singular=wdgt\n" +
+				"+protoc-gen-resource:short-names=wd,wdg\n+protoc-gen-resource:categories=all\n+protoc-gen-resource:scope=Cluster\n",
+			want: &resourceNames{
+				Plural:     "widgetry",
+				Singular:   "wdgt",
+				ShortNames: []string{"wd", "wdg"},
+				Categories: []string{"all"},
+				Scope:      scopeCluster,
+			},
+		},
+		{
+			name:     "Option and comments agree",
+			kind:     "Widget",
+			opts:     &protoc_gen_resource.ResourceOptions{ShortNames: []string{"wd", "wdg"}},
+			comments: "+protoc-gen-resource:short-names=wd,wdg\n",
+			want:     &resourceNames{Plural: "widgets", Singular: "widget", ShortNames: []string{"wd", "wdg"}, Scope: scopeNamespaced},
+		},
+		{
+			name:     "Option and comments disagree",
+			kind:     "Widget",
+			opts:     &protoc_gen_resource.ResourceOptions{Plural: "widgets"},
+			comments: "+protoc-gen-resource:plural=widgetry\n",
+			wantErr:  true,
+		},
+		{
+			name:     "Invalid plural",
+			kind:     "Widget",
+			comments: "+protoc-gen-resource:plural=Widgets\n",
+			wantErr:  true,
+		},
+		{
+			name:    "Invalid short name",
+			kind:    "Widget",
+			opts:    &protoc_gen_resource.ResourceOptions{ShortNames: []string{"wd", "w_d"}},
+			wantErr: true,
+		},
+		{
+			name:     "Invalid scope",
+			kind:     "Widget",
+			comments: "+protoc-gen-resource:scope=Global\n",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			_, m := newOptionsFile(t, nil, "", tt.opts, tt.comments)
+
+			got, err := resolveNames(m, &gvk{Group: "example.com", Version: "v1", Kind: tt.kind})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("resolveNames() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("resolveNames() got = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,206 @@
+package resource
+
+import (
+	"fmt"
+	"go/token"
+	"google.golang.org/protobuf/compiler/protogen"
+	"path"
+	"strconv"
+	"strings"
+)
+
+const (
+	// ResourcesAll makes all the messages resources unless other mode is configured for message.
+	ResourcesAll = "all"
+	// ResourcesAnnotated makes resources only top level messages with configured group, version, kind or mode.
+	// All the messages referenced by resources still have DeepCopy functions generated.
+	ResourcesAnnotated = "annotated"
+)
+
+// Default names of generated methods returning group, version and kind of resource.
+const (
+	DefaultGroupAccessor   = "GetResourceGroup"
+	DefaultVersionAccessor = "GetResourceVersion"
+	DefaultKindAccessor    = "GetResourceKind"
+)
+
+// DefaultCRDDir directory of generated CustomResourceDefinition manifests relative to output directory.
+const DefaultCRDDir = "crds"
+
+// Params holds plugin parameters passed by protoc as `--resource_opt=<name>=<value>`.
+type Params struct {
+	// Resources defines which messages are resources if mode is not configured for message.
+	// Either ResourcesAll (default) or ResourcesAnnotated.
+	Resources string
+
+	// PackageMapping rules to get group and version from protobuf package. If nil - DefaultPackageMapping is used.
+	// May be overridden by file option `(protoc_gen_resource.file_resource).package_mapping`.
+	PackageMapping *PackageMapping
+
+	// GroupAccessor name of generated method returning group of resource. If empty - DefaultGroupAccessor is used.
+	GroupAccessor string
+
+	// VersionAccessor name of generated method returning version of resource. If empty - DefaultVersionAccessor is used.
+	// Default name clashes with metav1.Object, so it must be changed for resources with metadata field.
+	VersionAccessor string
+
+	// KindAccessor name of generated method returning kind of resource. If empty - DefaultKindAccessor is used.
+	KindAccessor string
+
+	// CRDs enables generation of apiextensions.k8s.io/v1 CustomResourceDefinition manifests, one per group and kind.
+	CRDs bool
+
+	// CRDDir directory of generated CustomResourceDefinition manifests. If empty - DefaultCRDDir is used.
+	CRDDir string
+
+	// RegisterPerFile writes register helpers of go package to `<name>.register.pb.go` of its first file instead of
+	// `zz_generated.register.pb.go`, so build systems declaring outputs per proto file, like Bazel go_proto_compiler,
+	// know the file.
+	RegisterPerFile bool
+}
+
+// Set sets plugin parameter. It's used as protogen parameter function.
+// List values of package mapping parameters are separated by ':', e.g. `package_strip_suffixes=model:services`.
+func (p *Params) Set(name, value string) error {
+	if strings.HasPrefix(name, "package_") && p.PackageMapping == nil {
+		mapping := DefaultPackageMapping()
+		p.PackageMapping = &mapping
+	}
+
+	switch name {
+	case "resources":
+		if value != ResourcesAll && value != ResourcesAnnotated {
+			return fmt.Errorf("invalid value '%s' of parameter 'resources', must be one of '%s', '%s'",
+				value, ResourcesAll, ResourcesAnnotated)
+		}
+		p.Resources = value
+	case "package_strip_suffixes":
+		p.PackageMapping.StripSuffixes = splitList(value)
+	case "package_version_regex":
+		r, err := compileVersionRegex(value)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' of parameter '%s' : %w", value, name, err)
+		}
+		p.PackageMapping.VersionRegex = r
+	case "package_reverse":
+		reverse, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' of parameter '%s' : %w", value, name, err)
+		}
+		p.PackageMapping.Reverse = reverse
+	case "package_drop_segments":
+		p.PackageMapping.DropSegments = splitList(value)
+	case "package_add_segments":
+		p.PackageMapping.AddSegments = splitList(value)
+	case "package_group_suffix":
+		p.PackageMapping.GroupSuffix = value
+	case "group_accessor":
+		if err := validateAccessor(name, value); err != nil {
+			return err
+		}
+		p.GroupAccessor = value
+	case "version_accessor":
+		if err := validateAccessor(name, value); err != nil {
+			return err
+		}
+		p.VersionAccessor = value
+	case "kind_accessor":
+		if err := validateAccessor(name, value); err != nil {
+			return err
+		}
+		p.KindAccessor = value
+	case "crds":
+		crds, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' of parameter '%s' : %w", value, name, err)
+		}
+		p.CRDs = crds
+	case "crd_dir":
+		if value == "" || path.IsAbs(value) || path.Clean(value) != value || strings.HasPrefix(value, "..") {
+			return fmt.Errorf("invalid value '%s' of parameter '%s', must be clean relative path", value, name)
+		}
+		p.CRDDir = value
+	case "register_per_file":
+		perFile, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' of parameter '%s' : %w", value, name, err)
+		}
+		p.RegisterPerFile = perFile
+	default:
+		return fmt.Errorf("unknown parameter '%s'", name)
+	}
+
+	return nil
+}
+
+// annotatedOnly returns true if only annotated messages are resources.
+func (p *Params) annotatedOnly() bool {
+	return p != nil && p.Resources == ResourcesAnnotated
+}
+
+// packageMapping returns package mapping rules configured by parameters.
+func (p *Params) packageMapping() PackageMapping {
+	if p == nil || p.PackageMapping == nil {
+		return DefaultPackageMapping()
+	}
+	return *p.PackageMapping
+}
+
+// gvkAccessors returns names of generated methods returning group, version and kind of resource.
+func (p *Params) gvkAccessors() gvkAccessors {
+	accessors := gvkAccessors{
+		Group:   DefaultGroupAccessor,
+		Version: DefaultVersionAccessor,
+		Kind:    DefaultKindAccessor,
+	}
+	if p == nil {
+		return accessors
+	}
+	if p.GroupAccessor != "" {
+		accessors.Group = p.GroupAccessor
+	}
+	if p.VersionAccessor != "" {
+		accessors.Version = p.VersionAccessor
+	}
+	if p.KindAccessor != "" {
+		accessors.Kind = p.KindAccessor
+	}
+	return accessors
+}
+
+// crdsEnabled returns true if CustomResourceDefinition manifests are generated.
+func (p *Params) crdsEnabled() bool {
+	return p != nil && p.CRDs
+}
+
+// crdDir returns directory of generated CustomResourceDefinition manifests.
+func (p *Params) crdDir() string {
+	if p == nil || p.CRDDir == "" {
+		return DefaultCRDDir
+	}
+	return p.CRDDir
+}
+
+// registerFile returns name of generated register helpers file of go package with the first file of package provided.
+func (p *Params) registerFile(file *protogen.File) string {
+	if p != nil && p.RegisterPerFile {
+		return file.GeneratedFilenamePrefix + registerFileSuffix
+	}
+	return path.Join(path.Dir(file.GeneratedFilenamePrefix), registerFileName)
+}
+
+// validateAccessor checks that value of accessor parameter is exported go identifier.
+func validateAccessor(name, value string) error {
+	if !token.IsIdentifier(value) || !token.IsExported(value) {
+		return fmt.Errorf("invalid value '%s' of parameter '%s', must be exported go identifier", value, name)
+	}
+	return nil
+}
+
+// splitList splits list parameter value separated by ':'.
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ":")
+}
@@ -0,0 +1,200 @@
+package resource
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"go/format"
+	"google.golang.org/protobuf/compiler/protogen"
+	"sort"
+)
+
+//go:embed templates/register.gotmpl
+var registerTmpl string
+
+// registerFileName name of file with register helpers, it's generated once per go package.
+const registerFileName = "zz_generated.register.pb.go"
+
+// registerFileSuffix suffix of file with register helpers generated for the first file of go package
+// if parameter `register_per_file` is set.
+const registerFileSuffix = ".register.pb.go"
+
+// registeredResource holds resource of go package for register helpers.
+type registeredResource struct {
+	Type string
+	Gvk  *gvk
+}
+
+// generateRegister generates register helpers for go package of the file:
+// GroupName, SchemeGroupVersion, Kind and Resource if all the resources of package share group and version,
+// <Type>GroupVersionKind variable with GroupVersionKind method and <Type>ListGroupVersionKind variable for each resource
+// and SchemeBuilder with AddToScheme registering all the resources of package and their lists.
+// Helpers of the whole package are generated into single file together with the first file of the package,
+// so several proto files of the same go package don't produce duplicate symbols.
+func (p *Params) generateRegister(gen *protogen.Plugin, file *protogen.File) error {
+	var files []*protogen.File
+	for _, f := range gen.Files {
+		if f.Generate && f.GoImportPath == file.GoImportPath {
+			files = append(files, f)
+		}
+	}
+
+	if files[0] != file {
+		return nil
+	}
+
+	genFile := gen.NewGeneratedFile(p.registerFile(file), file.GoImportPath)
+
+	var resources []registeredResource
+	for _, f := range files {
+		g, err := newGenerator(p, gen, f, genFile)
+		if err != nil {
+			return err
+		}
+
+		for _, m := range g.order {
+			if g.modes[m] != modeResource {
+				continue
+			}
+
+			res, err := g.resolveGvk(m)
+			if err != nil {
+				return fmt.Errorf("unable to generate GVK for message '%s' : %w", m.GoIdent.GoName, err)
+			}
+			resources = append(resources, registeredResource{Type: m.GoIdent.GoName, Gvk: res})
+		}
+	}
+
+	// if no resources - skip generation
+	if len(resources) == 0 {
+		genFile.Skip()
+		return nil
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].Type < resources[j].Type
+	})
+
+	// each group version of the package is added to scheme once, ordered to keep generation deterministic
+	var groupVersions []*gvk
+	seen := make(map[gvk]string)
+	for _, r := range resources {
+		list := gvk{Group: r.Gvk.Group, Version: r.Gvk.Version, Kind: r.Gvk.Kind + listSuffix}
+		for _, registered := range []struct {
+			gvk  gvk
+			Type string
+		}{{gvk: *r.Gvk, Type: r.Type}, {gvk: list, Type: r.Type + listSuffix}} {
+			if clash, ok := seen[registered.gvk]; ok {
+				return fmt.Errorf("types '%s' and '%s' of go package %s have the same group '%s', version '%s' and kind '%s'",
+					clash, registered.Type, file.GoImportPath, registered.gvk.Group, registered.gvk.Version, registered.gvk.Kind)
+			}
+			seen[registered.gvk] = registered.Type
+		}
+
+		gv := &gvk{Group: r.Gvk.Group, Version: r.Gvk.Version}
+		if !containsGroupVersion(groupVersions, gv) {
+			groupVersions = append(groupVersions, gv)
+		}
+	}
+	sort.Slice(groupVersions, func(i, j int) bool {
+		if groupVersions[i].Group != groupVersions[j].Group {
+			return groupVersions[i].Group < groupVersions[j].Group
+		}
+		return groupVersions[i].Version < groupVersions[j].Version
+	})
+
+	// package level helpers are generated only if they are not ambiguous
+	helpers := []string{"SchemeBuilder", "AddToScheme"}
+	var groupVersion *gvk
+	if len(groupVersions) == 1 {
+		groupVersion = groupVersions[0]
+		helpers = append(helpers, "GroupName", "SchemeGroupVersion", "Kind", "Resource")
+	}
+	for _, r := range resources {
+		helpers = append(helpers, r.Type+"GroupVersionKind", r.Type+listSuffix, r.Type+listSuffix+"GroupVersionKind")
+	}
+
+	if err := checkRegisterClashes(files, helpers); err != nil {
+		return err
+	}
+
+	sw := templates.NewSnippetWriter(bytes.NewBuffer([]byte{}), "{{", "}}", nil)
+	sw.Do(registerTmpl, templates.Args{
+		"package":       string(file.GoPackageName),
+		"groupVersion":  groupVersion,
+		"groupVersions": groupVersions,
+		"resources":     resources,
+	})
+	if sw.Error() != nil {
+		return fmt.Errorf("unable to generate register helpers for go package %s : %w", file.GoImportPath, sw.Error())
+	}
+
+	formattedSources, err := format.Source([]byte(fmt.Sprintf("%v", sw.Out())))
+	if err != nil {
+		return fmt.Errorf("unable to format generated sources : %w", err)
+	}
+
+	_, err = genFile.Write(formattedSources)
+
+	return err
+}
+
+// checkRegisterClashes returns error if any of go identifiers generated from protobuf files of the package
+// clashes with register helpers or generated lists.
+func checkRegisterClashes(files []*protogen.File, helpers []string) error {
+	for _, f := range files {
+		for _, ident := range goIdents(f) {
+			if contains(helpers, ident) {
+				return fmt.Errorf("go identifier '%s' generated from file '%s' clashes with generated register helper or list, "+
+					"rename protobuf definition", ident, f.Desc.Path())
+			}
+		}
+	}
+
+	return nil
+}
+
+// containsGroupVersion returns true if group versions contain group and version of provided one.
+func containsGroupVersion(groupVersions []*gvk, gv *gvk) bool {
+	for _, candidate := range groupVersions {
+		if candidate.Group == gv.Group && candidate.Version == gv.Version {
+			return true
+		}
+	}
+	return false
+}
+
+// goIdents returns names of all the go identifiers declared for protobuf file: messages, enums, enum values and
+// oneof wrappers.
+func goIdents(f *protogen.File) []string {
+	var idents []string
+
+	addEnums := func(enums []*protogen.Enum) {
+		for _, e := range enums {
+			idents = append(idents, e.GoIdent.GoName)
+			for _, v := range e.Values {
+				idents = append(idents, v.GoIdent.GoName)
+			}
+		}
+	}
+
+	var addMessages func(messages []*protogen.Message)
+	addMessages = func(messages []*protogen.Message) {
+		for _, m := range messages {
+			idents = append(idents, m.GoIdent.GoName)
+			for _, o := range m.Oneofs {
+				for _, field := range o.Fields {
+					idents = append(idents, field.GoIdent.GoName)
+				}
+			}
+			addEnums(m.Enums)
+			addMessages(m.Messages)
+		}
+	}
+
+	addEnums(f.Enums)
+	addMessages(f.Messages)
+
+	return idents
+}
@@ -0,0 +1,157 @@
+package resource
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_checkRegisterClashes(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "register_clash.descriptor"), "register_clash.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+	files := []*protogen.File{gen.FilesByPath["register_clash.proto"]}
+
+	tests := []struct {
+		name    string
+		helpers []string
+		wantErr string
+	}{
+		{
+			name:    "Package Helper",
+			helpers: []string{"SchemeBuilder", "AddToScheme", "GroupName", "SchemeGroupVersion", "Kind", "Resource"},
+			wantErr: "go identifier 'Kind' generated from file 'register_clash.proto' clashes",
+		},
+		{
+			name:    "Resource Helper",
+			helpers: []string{"SchemeBuilder", "AddToScheme", "WidgetGroupVersionKind"},
+			wantErr: "go identifier 'WidgetGroupVersionKind' generated from file 'register_clash.proto' clashes",
+		},
+		{
+			name:    "No Clashes",
+			helpers: []string{"SchemeBuilder", "AddToScheme", "WidgetGroupVersionKindGroupVersionKind"},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkRegisterClashes(files, tt.helpers)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("checkRegisterClashes() unexpected error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("checkRegisterClashes() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_generateRegister_duplicateGvk(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "register_duplicate.descriptor"), "register_duplicate.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+
+	err = (&Params{}).generateRegister(gen, gen.FilesByPath["register_duplicate.proto"])
+	wantErr := "types 'WidgetV1' and 'WidgetV2' of go package \"github.com/dgodyna/protoc-gen-resource/pkg/deepcopy/testdata/protos\" " +
+		"have the same group 'test.api.nrm.netcracker.com', version 'v1' and kind 'Widget'"
+	if err == nil || err.Error() != wantErr {
+		t.Errorf("generateRegister() error = %v, want %q", err, wantErr)
+	}
+}
+
+func Test_generateRegister_listClash(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "list_clash.descriptor"), "list_clash.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+
+	err = (&Params{}).generateRegister(gen, gen.FilesByPath["list_clash.proto"])
+	wantErr := "go identifier 'WidgetList' generated from file 'list_clash.proto' clashes with generated register helper or list"
+	if err == nil || !strings.Contains(err.Error(), wantErr) {
+		t.Errorf("generateRegister() error = %v, want containing %q", err, wantErr)
+	}
+}
+
+func Test_generateRegister_fileName(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter string
+		// wantFile returns expected name of register helpers file by the first file of go package
+		wantFile func(file *protogen.File) string
+	}{
+		{
+			name: "Per Package",
+			wantFile: func(file *protogen.File) string {
+				return path.Join(path.Dir(file.GeneratedFilenamePrefix), registerFileName)
+			},
+		},
+		{
+			name:      "Per File",
+			parameter: "register_per_file=true",
+			wantFile: func(file *protogen.File) string {
+				return file.GeneratedFilenamePrefix + registerFileSuffix
+			},
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "register.descriptor"), "register_a.proto", "register_b.proto")
+			if err != nil {
+				t.Fatalf("unable to create code generation request: %v", err)
+			}
+			req.Parameter = proto.String(tt.parameter)
+			params := &Params{}
+			gen, err := protoc.NewPlugin(req, params.Set)
+			if err != nil {
+				t.Fatalf("unable to create protogen plugin: %v", err)
+			}
+
+			for _, file := range []string{"register_a.proto", "register_b.proto"} {
+				if err := params.Generate(gen, file); err != nil {
+					t.Fatalf("Generate() unexpected error = %v", err)
+				}
+			}
+
+			// register helpers are generated together with the first file of go package in order of request
+			var first *protogen.File
+			for _, f := range gen.Files {
+				if f.Generate {
+					first = f
+					break
+				}
+			}
+			wantFile := tt.wantFile(first)
+			var registerFiles []string
+			for _, f := range gen.Response().File {
+				if !strings.HasSuffix(f.GetName(), ".deepcopy.pb.go") {
+					registerFiles = append(registerFiles, f.GetName())
+				}
+			}
+			if len(registerFiles) != 1 || registerFiles[0] != wantFile {
+				t.Errorf("generated register files = %v, want [%s]", registerFiles, wantFile)
+			}
+		})
+	}
+}
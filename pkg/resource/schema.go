@@ -0,0 +1,252 @@
+package resource
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"strings"
+)
+
+// Types and formats of OpenAPI v3 schema.
+const (
+	schemaTypeObject  = "object"
+	schemaTypeArray   = "array"
+	schemaTypeString  = "string"
+	schemaTypeInteger = "integer"
+	schemaTypeNumber  = "number"
+	schemaTypeBoolean = "boolean"
+
+	schemaFormatInt32    = "int32"
+	schemaFormatInt64    = "int64"
+	schemaFormatFloat    = "float"
+	schemaFormatDouble   = "double"
+	schemaFormatByte     = "byte"
+	schemaFormatDateTime = "date-time"
+)
+
+// jsonSchemaProps is structural OpenAPI v3 schema, subset of apiextensions.k8s.io/v1 JSONSchemaProps.
+type jsonSchemaProps struct {
+	Description            string                      `json:"description,omitempty"`
+	Type                   string                      `json:"type,omitempty"`
+	Format                 string                      `json:"format,omitempty"`
+	Nullable               bool                        `json:"nullable,omitempty"`
+	Default                interface{}                 `json:"default,omitempty"`
+	Enum                   []string                    `json:"enum,omitempty"`
+	Minimum                *float64                    `json:"minimum,omitempty"`
+	Maximum                *float64                    `json:"maximum,omitempty"`
+	MinLength              *int64                      `json:"minLength,omitempty"`
+	MaxLength              *int64                      `json:"maxLength,omitempty"`
+	Pattern                string                      `json:"pattern,omitempty"`
+	MinItems               *int64                      `json:"minItems,omitempty"`
+	MaxItems               *int64                      `json:"maxItems,omitempty"`
+	Items                  *jsonSchemaProps            `json:"items,omitempty"`
+	Properties             map[string]*jsonSchemaProps `json:"properties,omitempty"`
+	AdditionalProperties   *jsonSchemaProps            `json:"additionalProperties,omitempty"`
+	Required               []string                    `json:"required,omitempty"`
+	XPreserveUnknownFields bool                        `json:"x-kubernetes-preserve-unknown-fields,omitempty"`
+}
+
+// resourceSchema returns OpenAPI v3 schema of resource following proto3 JSON mapping with lowerCamelCase field names.
+// apiVersion and kind are added to properties and metadata field is left to kubernetes as plain object.
+func resourceSchema(m *protogen.Message) (*jsonSchemaProps, error) {
+	schema := &jsonSchemaProps{
+		Description: schemaDescription(m.Comments.Leading),
+		Type:        schemaTypeObject,
+		Properties: map[string]*jsonSchemaProps{
+			"apiVersion": {
+				Description: "APIVersion defines the versioned schema of this representation of an object.",
+				Type:        schemaTypeString,
+			},
+			"kind": {
+				Description: "Kind is a string value representing the REST resource this object represents.",
+				Type:        schemaTypeString,
+			},
+		},
+	}
+
+	stack := map[*protogen.Message]bool{m: true}
+	for _, field := range m.Fields {
+		role, err := fieldRole(field)
+		if err != nil {
+			return nil, err
+		}
+
+		name := field.Desc.JSONName()
+		if _, ok := schema.Properties[name]; ok {
+			return nil, fmt.Errorf("%s: field '%s' of message '%s' clashes with '%s' property of resource",
+				fieldPosition(field), field.Desc.Name(), m.GoIdent.GoName, name)
+		}
+
+		if role == fieldRoleMetadata {
+			schema.Properties[name] = &jsonSchemaProps{Type: schemaTypeObject}
+			continue
+		}
+		if err := addProperty(schema, field, stack); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// addProperty adds schema of the field with its constraints to properties of object schema.
+func addProperty(schema *jsonSchemaProps, field *protogen.Field, stack map[*protogen.Message]bool) error {
+	c, err := resolveConstraints(field)
+	if err != nil {
+		return err
+	}
+
+	property, err := fieldSchema(field, stack)
+	if err != nil {
+		return err
+	}
+	c.apply(property)
+
+	if schema.Properties == nil {
+		schema.Properties = make(map[string]*jsonSchemaProps)
+	}
+	schema.Properties[field.Desc.JSONName()] = property
+	if c.Required {
+		schema.Required = append(schema.Required, field.Desc.JSONName())
+	}
+
+	return nil
+}
+
+// fieldSchema returns schema of message field: array for repeated fields, object with additional properties for maps
+// and schema of value otherwise. Description is taken from leading comments of the field.
+func fieldSchema(field *protogen.Field, stack map[*protogen.Message]bool) (*jsonSchemaProps, error) {
+	var schema *jsonSchemaProps
+	switch {
+	case field.Desc.IsMap():
+		// keys of maps are always strings in JSON
+		value, err := valueSchema(field.Message.Fields[1], stack)
+		if err != nil {
+			return nil, err
+		}
+		schema = &jsonSchemaProps{Type: schemaTypeObject, AdditionalProperties: value}
+	case field.Desc.IsList():
+		items, err := valueSchema(field, stack)
+		if err != nil {
+			return nil, err
+		}
+		schema = &jsonSchemaProps{Type: schemaTypeArray, Items: items}
+	default:
+		value, err := valueSchema(field, stack)
+		if err != nil {
+			return nil, err
+		}
+		schema = value
+	}
+
+	schema.Description = schemaDescription(field.Comments.Leading)
+
+	return schema, nil
+}
+
+// valueSchema returns schema of single value of the field.
+func valueSchema(field *protogen.Field, stack map[*protogen.Message]bool) (*jsonSchemaProps, error) {
+	switch field.Desc.Kind() {
+	case protoreflect.StringKind:
+		return &jsonSchemaProps{Type: schemaTypeString}, nil
+	case protoreflect.BytesKind:
+		return &jsonSchemaProps{Type: schemaTypeString, Format: schemaFormatByte}, nil
+	case protoreflect.BoolKind:
+		return &jsonSchemaProps{Type: schemaTypeBoolean}, nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return &jsonSchemaProps{Type: schemaTypeInteger, Format: schemaFormatInt32}, nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		// unsigned 32 bit integers don't fit into int32 format
+		return &jsonSchemaProps{Type: schemaTypeInteger, Format: schemaFormatInt64}, nil
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// 64 bit integers are encoded as strings by proto3 JSON mapping
+		return &jsonSchemaProps{Type: schemaTypeString, Format: schemaFormatInt64}, nil
+	case protoreflect.FloatKind:
+		return &jsonSchemaProps{Type: schemaTypeNumber, Format: schemaFormatFloat}, nil
+	case protoreflect.DoubleKind:
+		return &jsonSchemaProps{Type: schemaTypeNumber, Format: schemaFormatDouble}, nil
+	case protoreflect.EnumKind:
+		schema := &jsonSchemaProps{Type: schemaTypeString}
+		for _, v := range field.Enum.Values {
+			schema.Enum = append(schema.Enum, string(v.Desc.Name()))
+		}
+		return schema, nil
+	default:
+		return messageSchema(field.Message, stack)
+	}
+}
+
+// messageSchema returns schema of message value.
+// Recursive messages can't be described by structural schema, so recursion is cut by object preserving unknown fields.
+func messageSchema(m *protogen.Message, stack map[*protogen.Message]bool) (*jsonSchemaProps, error) {
+	if schema, ok := wellKnownSchema(m); ok {
+		return schema, nil
+	}
+	if stack[m] {
+		return &jsonSchemaProps{Type: schemaTypeObject, XPreserveUnknownFields: true}, nil
+	}
+
+	stack[m] = true
+	defer delete(stack, m)
+
+	schema := &jsonSchemaProps{Type: schemaTypeObject}
+	for _, field := range m.Fields {
+		if err := addProperty(schema, field, stack); err != nil {
+			return nil, err
+		}
+	}
+
+	return schema, nil
+}
+
+// wellKnownSchema returns schema of well-known type according to its special JSON representation.
+// If message is not a well-known type - false will be returned.
+func wellKnownSchema(m *protogen.Message) (*jsonSchemaProps, bool) {
+	switch m.Desc.FullName() {
+	case "google.protobuf.Timestamp":
+		return &jsonSchemaProps{Type: schemaTypeString, Format: schemaFormatDateTime}, true
+	case "google.protobuf.Duration", "google.protobuf.FieldMask":
+		return &jsonSchemaProps{Type: schemaTypeString}, true
+	case "google.protobuf.DoubleValue":
+		return &jsonSchemaProps{Type: schemaTypeNumber, Format: schemaFormatDouble, Nullable: true}, true
+	case "google.protobuf.FloatValue":
+		return &jsonSchemaProps{Type: schemaTypeNumber, Format: schemaFormatFloat, Nullable: true}, true
+	case "google.protobuf.Int64Value", "google.protobuf.UInt64Value":
+		return &jsonSchemaProps{Type: schemaTypeString, Format: schemaFormatInt64, Nullable: true}, true
+	case "google.protobuf.UInt32Value":
+		return &jsonSchemaProps{Type: schemaTypeInteger, Format: schemaFormatInt64, Nullable: true}, true
+	case "google.protobuf.Int32Value":
+		return &jsonSchemaProps{Type: schemaTypeInteger, Format: schemaFormatInt32, Nullable: true}, true
+	case "google.protobuf.BoolValue":
+		return &jsonSchemaProps{Type: schemaTypeBoolean, Nullable: true}, true
+	case "google.protobuf.StringValue":
+		return &jsonSchemaProps{Type: schemaTypeString, Nullable: true}, true
+	case "google.protobuf.BytesValue":
+		return &jsonSchemaProps{Type: schemaTypeString, Format: schemaFormatByte, Nullable: true}, true
+	case "google.protobuf.Empty":
+		return &jsonSchemaProps{Type: schemaTypeObject}, true
+	case "google.protobuf.Struct", "google.protobuf.Any":
+		return &jsonSchemaProps{Type: schemaTypeObject, XPreserveUnknownFields: true}, true
+	case "google.protobuf.ListValue":
+		return &jsonSchemaProps{Type: schemaTypeArray, Items: &jsonSchemaProps{XPreserveUnknownFields: true}}, true
+	case "google.protobuf.Value":
+		return &jsonSchemaProps{XPreserveUnknownFields: true}, true
+	}
+
+	return nil, false
+}
+
+// schemaDescription returns description of schema from leading comments without marker lines.
+func schemaDescription(comments protogen.Comments) string {
+	var lines []string
+	for _, line := range strings.Split(string(comments), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "+") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,82 @@
+package resource
+
+import (
+	_ "embed"
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+//go:embed templates/status.gotmpl
+var statusTmpl string
+
+// genStatus generates GetSpec, GetStatus, SetStatus and CopyStatusFrom methods of resource for its spec and status
+// fields. Getters are generated only if they are not generated by protoc-gen-go already,
+// i.e. if the field is named differently.
+func (g *generator) genStatus(m *protogen.Message) error {
+	spec, err := roleField(m, fieldRoleSpec)
+	if err != nil {
+		return err
+	}
+	status, err := roleField(m, fieldRoleStatus)
+	if err != nil {
+		return err
+	}
+	if spec == nil && status == nil {
+		return nil
+	}
+
+	if err := checkStatusClashes(m, spec, status); err != nil {
+		return err
+	}
+
+	args := templates.Args{"type": m.GoIdent.GoName}
+	if spec != nil {
+		args["spec"] = spec.GoName
+		args["specType"] = g.genFile.QualifiedGoIdent(spec.Message.GoIdent)
+	}
+	if status != nil {
+		copyExpr, _ := g.messageCopy(status.Message, "other."+status.GoName)
+		args["status"] = status.GoName
+		args["statusType"] = g.genFile.QualifiedGoIdent(status.Message.GoIdent)
+		args["statusCopy"] = copyExpr
+	}
+
+	g.sw.Do(statusTmpl, args)
+
+	return nil
+}
+
+// checkStatusClashes returns error if GetSpec or GetStatus generated for spec or status field clashes with getter
+// generated by protoc-gen-go for another field or oneof of resource.
+func checkStatusClashes(m *protogen.Message, spec, status *protogen.Field) error {
+	type roleAccessor struct {
+		role  string
+		field *protogen.Field
+	}
+	methods := make(map[string]roleAccessor)
+	if spec != nil {
+		methods["GetSpec"] = roleAccessor{role: fieldRoleSpec, field: spec}
+	}
+	if status != nil {
+		methods["GetStatus"] = roleAccessor{role: fieldRoleStatus, field: status}
+	}
+
+	f := m.Desc.ParentFile()
+	for _, field := range m.Fields {
+		if a, ok := methods["Get"+field.GoName]; ok && a.field != field {
+			return fmt.Errorf("%s: getter 'Get%s' of field '%s' clashes with accessor of %s field '%s' of message '%s', "+
+				"rename the field", fieldPosition(field), field.GoName, field.Desc.Name(), a.role, a.field.Desc.Name(),
+				m.GoIdent.GoName)
+		}
+	}
+	for _, o := range m.Oneofs {
+		if a, ok := methods["Get"+o.GoName]; ok {
+			return fmt.Errorf("%s: getter 'Get%s' of oneof '%s' clashes with accessor of %s field '%s' of message '%s', "+
+				"rename the oneof", position(f, f.SourceLocations().ByDescriptor(o.Desc).Path), o.GoName, o.Desc.Name(),
+				a.role, a.field.Desc.Name(), m.GoIdent.GoName)
+		}
+	}
+
+	return nil
+}
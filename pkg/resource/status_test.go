@@ -0,0 +1,64 @@
+package resource
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	"google.golang.org/protobuf/compiler/protogen"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func Test_genMessage_specStatus(t *testing.T) {
+	req, err := protoc.ReadCodeGenerationRequest(filepath.Join("testdata", "descriptors", "invalid_spec_status.descriptor"), "invalid_spec_status.proto")
+	if err != nil {
+		t.Fatalf("unable to create code generation request: %v", err)
+	}
+	gen, err := protoc.NewPlugin(req, nil)
+	if err != nil {
+		t.Fatalf("unable to create protogen plugin: %v", err)
+	}
+	file := gen.FilesByPath["invalid_spec_status.proto"]
+
+	tests := []struct {
+		message string
+		wantErr string
+	}{
+		{
+			message: "TwoStatusFields",
+			wantErr: "unable to generate spec and status accessors of message 'TwoStatusFields' : " +
+				"invalid_spec_status.proto:16: message 'TwoStatusFields' has more than one status field: 'status' and 'observed'",
+		},
+		{
+			message: "ScalarStatus",
+			wantErr: "unable to generate spec and status accessors of message 'ScalarStatus' : " +
+				"invalid_spec_status.proto:21: status field 'status' of message 'ScalarStatus' must be singular message field",
+		},
+		{
+			message: "GetterClash",
+			wantErr: "unable to generate spec and status accessors of message 'GetterClash' : " +
+				"invalid_spec_status.proto:27: getter 'GetSpec' of field 'spec' clashes with accessor of spec field 'desired'",
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.message, func(t *testing.T) {
+			var m *protogen.Message
+			for _, candidate := range file.Messages {
+				if candidate.GoIdent.GoName == tt.message {
+					m = candidate
+				}
+			}
+
+			genFile := gen.NewGeneratedFile("test.go", file.GoImportPath)
+			g, err := newGenerator(&Params{}, gen, file, genFile)
+			if err != nil {
+				t.Fatalf("unable to create generator: %v", err)
+			}
+
+			err = g.genMessage(m)
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr) {
+				t.Errorf("genMessage() error = %v, want prefix %q", err, tt.wantErr)
+			}
+		})
+	}
+}
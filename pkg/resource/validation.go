@@ -0,0 +1,112 @@
+package resource
+
+import (
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/protoc_gen_resource"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"regexp"
+	"strings"
+)
+
+// field numbers of google.protobuf.DescriptorProto, google.protobuf.FieldDescriptorProto and
+// google.protobuf.FileDescriptorProto options used to find location of options.
+const (
+	messageOptionsField = 7
+	fieldOptionsField   = 8
+	fileOptionsField    = 8
+)
+
+// field numbers of extensions declared in protoc_gen_resource/options.proto used to find location of options,
+// they are taken from generated extensions, so they follow options.proto.
+var (
+	messageResourceField = int32(protoc_gen_resource.E_Resource.TypeDescriptor().Number())
+	fileResourceField    = int32(protoc_gen_resource.E_FileResource.TypeDescriptor().Number())
+	fieldResourceField   = int32(protoc_gen_resource.E_Field.TypeDescriptor().Number())
+)
+
+var (
+	// versionRegex kubernetes version format.
+	versionRegex = regexp.MustCompile(`^v\d+((alpha|beta)\d+)?$`)
+	// kindRegex UpperCamelCase kind.
+	kindRegex = regexp.MustCompile(`^[A-Z][a-zA-Z0-9]*$`)
+)
+
+// hubVersion is internal version which is allowed in addition to kubernetes versions.
+const hubVersion = "hub"
+
+// validateGvk checks GVK against kubernetes naming rules:
+// group must be DNS-1123 subdomain, version must be 'hub' or follow v\d+((alpha|beta)\d+)? format,
+// kind must be UpperCamelCase.
+// groupAt, versionAt and kindAt are positions of values in proto file to point to in errors.
+func validateGvk(m *protogen.Message, res *gvk, groupAt, versionAt, kindAt string) error {
+	if errs := validation.IsDNS1123Subdomain(res.Group); len(errs) > 0 {
+		return fmt.Errorf("%s: invalid group '%s' of message '%s', group must be a DNS-1123 subdomain: %s",
+			groupAt, res.Group, m.GoIdent.GoName, strings.Join(errs, "; "))
+	}
+
+	if res.Version != hubVersion && !versionRegex.MatchString(res.Version) {
+		return fmt.Errorf("%s: invalid version '%s' of message '%s', version must be '%s' or follow %s format",
+			versionAt, res.Version, m.GoIdent.GoName, hubVersion, versionRegex.String())
+	}
+
+	if !kindRegex.MatchString(res.Kind) {
+		return fmt.Errorf("%s: invalid kind '%s' of message '%s', kind must be UpperCamelCase",
+			kindAt, res.Kind, m.GoIdent.GoName)
+	}
+
+	return nil
+}
+
+// position returns '<proto file>:<line>' of first found source location by paths.
+// If no location is found - only proto file is returned.
+func position(f protoreflect.FileDescriptor, paths ...protoreflect.SourcePath) string {
+	for _, path := range paths {
+		// empty path is location of the whole file
+		if len(path) == 0 {
+			continue
+		}
+		if loc := f.SourceLocations().ByPath(path); loc.Path != nil {
+			return fmt.Sprintf("%s:%d", f.Path(), loc.StartLine+1)
+		}
+	}
+
+	return f.Path()
+}
+
+// markerPosition returns '<proto file>:<line>' of '+protoc-gen-resource:<name>=' marker in leading comments
+// of first source location by path which contains it.
+// If marker is not found - position of first found location by paths is returned.
+func markerPosition(f protoreflect.FileDescriptor, name string, paths ...protoreflect.SourcePath) string {
+	marker := "+protoc-gen-resource:" + name + "="
+
+	for _, path := range paths {
+		loc := f.SourceLocations().ByPath(path)
+		idx := strings.Index(loc.LeadingComments, marker)
+		if len(path) == 0 || loc.Path == nil || idx < 0 {
+			continue
+		}
+
+		// leading comments end right before the statement, so count lines back from its start
+		commentLines := strings.Count(strings.TrimSuffix(loc.LeadingComments, "\n"), "\n") + 1
+		markerLine := strings.Count(loc.LeadingComments[:idx], "\n")
+
+		return fmt.Sprintf("%s:%d", f.Path(), loc.StartLine-commentLines+markerLine+1)
+	}
+
+	return position(f, paths...)
+}
+
+// messagePath returns source path of message.
+func messagePath(m *protogen.Message) protoreflect.SourcePath {
+	return m.Desc.ParentFile().SourceLocations().ByDescriptor(m.Desc).Path
+}
+
+// messageOptionPath returns source path of `(protoc_gen_resource.resource)` option of message.
+func messageOptionPath(m *protogen.Message) protoreflect.SourcePath {
+	if len(messagePath(m)) == 0 {
+		return nil
+	}
+	return append(append(protoreflect.SourcePath{}, messagePath(m)...), messageOptionsField, messageResourceField)
+}
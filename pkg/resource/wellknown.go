@@ -0,0 +1,41 @@
+package resource
+
+import (
+	"fmt"
+	"google.golang.org/protobuf/compiler/protogen"
+)
+
+// wellKnownPackage holds import path of package with deepcopy functions for recursive well-known types.
+const wellKnownPackage = protogen.GoImportPath("github.com/dgodyna/protoc-gen-resource/pkg/wellknown")
+
+// wellKnownCopy returns go expression which copies non-nil value `in` of well-known type.
+// Flat well-known types are copied field by field right in place, recursive ones are copied by functions
+// from wellknown package.
+// If message is not a supported well-known type - false will be returned.
+func (g *generator) wellKnownCopy(message *protogen.Message, in string) (string, bool) {
+	// go type is qualified only when it's rendered, otherwise the import of its package would be left unused
+	goType := func() string {
+		return g.genFile.QualifiedGoIdent(message.GoIdent)
+	}
+
+	switch message.Desc.FullName() {
+	case "google.protobuf.Timestamp", "google.protobuf.Duration":
+		return fmt.Sprintf("&%s{Seconds: %s.Seconds, Nanos: %s.Nanos}", goType(), in, in), true
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue", "google.protobuf.Int64Value", "google.protobuf.UInt64Value",
+		"google.protobuf.Int32Value", "google.protobuf.UInt32Value", "google.protobuf.BoolValue", "google.protobuf.StringValue":
+		return fmt.Sprintf("&%s{Value: %s.Value}", goType(), in), true
+	case "google.protobuf.BytesValue":
+		return fmt.Sprintf("&%s{Value: append([]byte(nil), %s.Value...)}", goType(), in), true
+	case "google.protobuf.FieldMask":
+		return fmt.Sprintf("&%s{Paths: append([]string(nil), %s.Paths...)}", goType(), in), true
+	case "google.protobuf.Any":
+		return fmt.Sprintf("&%s{TypeUrl: %s.TypeUrl, Value: append([]byte(nil), %s.Value...)}", goType(), in, in), true
+	case "google.protobuf.Empty":
+		return fmt.Sprintf("&%s{}", goType()), true
+	case "google.protobuf.Struct", "google.protobuf.ListValue", "google.protobuf.Value":
+		copyFunc := g.genFile.QualifiedGoIdent(wellKnownPackage.Ident("DeepCopy" + string(message.Desc.Name())))
+		return fmt.Sprintf("%s(%s)", copyFunc, in), true
+	}
+
+	return "", false
+}
@@ -0,0 +1,61 @@
+// Package wellknown contains deepcopy functions for protobuf well-known types which can't be copied
+// by a flat field copy, because they are recursive. Generated DeepCopyInto functions call them for
+// fields of such types.
+package wellknown
+
+import (
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+// DeepCopyStruct returns deep copy of provided google.protobuf.Struct.
+func DeepCopyStruct(in *structpb.Struct) *structpb.Struct {
+	if in == nil {
+		return nil
+	}
+	out := new(structpb.Struct)
+	if in.Fields != nil {
+		out.Fields = make(map[string]*structpb.Value, len(in.Fields))
+		for key, val := range in.Fields {
+			out.Fields[key] = DeepCopyValue(val)
+		}
+	}
+	return out
+}
+
+// DeepCopyListValue returns deep copy of provided google.protobuf.ListValue.
+func DeepCopyListValue(in *structpb.ListValue) *structpb.ListValue {
+	if in == nil {
+		return nil
+	}
+	out := new(structpb.ListValue)
+	if in.Values != nil {
+		out.Values = make([]*structpb.Value, len(in.Values))
+		for i := range in.Values {
+			out.Values[i] = DeepCopyValue(in.Values[i])
+		}
+	}
+	return out
+}
+
+// DeepCopyValue returns deep copy of provided google.protobuf.Value.
+func DeepCopyValue(in *structpb.Value) *structpb.Value {
+	if in == nil {
+		return nil
+	}
+	out := new(structpb.Value)
+	switch kind := in.Kind.(type) {
+	case *structpb.Value_NullValue:
+		out.Kind = &structpb.Value_NullValue{NullValue: kind.NullValue}
+	case *structpb.Value_NumberValue:
+		out.Kind = &structpb.Value_NumberValue{NumberValue: kind.NumberValue}
+	case *structpb.Value_StringValue:
+		out.Kind = &structpb.Value_StringValue{StringValue: kind.StringValue}
+	case *structpb.Value_BoolValue:
+		out.Kind = &structpb.Value_BoolValue{BoolValue: kind.BoolValue}
+	case *structpb.Value_StructValue:
+		out.Kind = &structpb.Value_StructValue{StructValue: DeepCopyStruct(kind.StructValue)}
+	case *structpb.Value_ListValue:
+		out.Kind = &structpb.Value_ListValue{ListValue: DeepCopyListValue(kind.ListValue)}
+	}
+	return out
+}
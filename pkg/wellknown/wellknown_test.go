@@ -0,0 +1,46 @@
+package wellknown
+
+import (
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gotest.tools/assert"
+	"testing"
+)
+
+func TestDeepCopyStruct(t *testing.T) {
+	original, err := structpb.NewStruct(map[string]interface{}{
+		"null":   nil,
+		"number": 42,
+		"string": "the answer",
+		"bool":   true,
+		"struct": map[string]interface{}{
+			"nested": "value",
+		},
+		"list": []interface{}{"a", 42, map[string]interface{}{"in": "list"}},
+	})
+	assert.NilError(t, err)
+
+	doppelganger := DeepCopyStruct(original)
+	assert.DeepEqual(t, original, doppelganger, protocmp.Transform())
+
+	// change the original in place
+	original.Fields["struct"].GetStructValue().Fields["nested"] = structpb.NewStringValue("changed")
+	original.Fields["list"].GetListValue().Values[0] = structpb.NewStringValue("changed")
+	original.Fields["list"].GetListValue().Values[2].GetStructValue().Fields["in"] = structpb.NewStringValue("changed")
+
+	assert.Equal(t, "value", doppelganger.Fields["struct"].GetStructValue().Fields["nested"].GetStringValue())
+	assert.Equal(t, "a", doppelganger.Fields["list"].GetListValue().Values[0].GetStringValue())
+	assert.Equal(t, "list", doppelganger.Fields["list"].GetListValue().Values[2].GetStructValue().Fields["in"].GetStringValue())
+}
+
+func TestDeepCopyNil(t *testing.T) {
+	assert.Assert(t, DeepCopyStruct(nil) == nil)
+	assert.Assert(t, DeepCopyListValue(nil) == nil)
+	assert.Assert(t, DeepCopyValue(nil) == nil)
+
+	// empty and nil collections stay distinct
+	assert.Assert(t, DeepCopyStruct(&structpb.Struct{}).Fields == nil)
+	assert.Assert(t, DeepCopyStruct(&structpb.Struct{Fields: map[string]*structpb.Value{}}).Fields != nil)
+	assert.Assert(t, DeepCopyListValue(&structpb.ListValue{}).Values == nil)
+	assert.Assert(t, DeepCopyListValue(&structpb.ListValue{Values: []*structpb.Value{}}).Values != nil)
+}
@@ -0,0 +1,44 @@
+package v1
+
+// Conversions of Time are written by hand, conversions of other messages are generated field by field from meta.proto
+// together with its go code, protoc and protoc-gen-go are expected in PATH.
+//go:generate go install ../../../cmd/protoc-gen-resource ./internal/protoc-gen-conversion
+//go:generate protoc -I ../../.. --go_out=../../.. --go_opt=module=github.com/dgodyna/protoc-gen-resource --resource_out=../../.. --resource_opt=module=github.com/dgodyna/protoc-gen-resource --conversion_out=../../.. --conversion_opt=module=github.com/dgodyna/protoc-gen-resource protoc_gen_resource/meta/v1/meta.proto
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"time"
+)
+
+// TimeToMetav1 converts Time to metav1.Time. Nil is converted to zero time.
+func TimeToMetav1(in *Time) metav1.Time {
+	if in == nil {
+		return metav1.Time{}
+	}
+	return metav1.NewTime(time.Unix(in.Seconds, int64(in.Nanos)))
+}
+
+// TimeFromMetav1 converts metav1.Time to Time. Zero time is converted to nil.
+func TimeFromMetav1(in metav1.Time) *Time {
+	if in.Time.IsZero() {
+		return nil
+	}
+	return &Time{Seconds: in.Unix(), Nanos: int32(in.Nanosecond())}
+}
+
+// TimeToMetav1Ptr converts Time to *metav1.Time. Nil is converted to nil.
+func TimeToMetav1Ptr(in *Time) *metav1.Time {
+	if in == nil {
+		return nil
+	}
+	out := TimeToMetav1(in)
+	return &out
+}
+
+// TimeFromMetav1Ptr converts *metav1.Time to Time. Nil and zero time are converted to nil.
+func TimeFromMetav1Ptr(in *metav1.Time) *Time {
+	if in == nil {
+		return nil
+	}
+	return TimeFromMetav1(*in)
+}
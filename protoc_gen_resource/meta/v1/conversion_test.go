@@ -0,0 +1,156 @@
+package v1
+
+import (
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/testing/protocmp"
+	"gotest.tools/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestObjectMetaConversion(t *testing.T) {
+	created := metav1.NewTime(time.Date(2021, 11, 1, 10, 0, 0, 123456789, time.Local))
+	deleted := metav1.NewTime(time.Date(2021, 11, 2, 10, 0, 0, 0, time.Local))
+	gracePeriod := int64(30)
+	controller := true
+
+	original := metav1.ObjectMeta{
+		Name:                       "widget",
+		GenerateName:               "widget-",
+		Namespace:                  "default",
+		SelfLink:                   "/apis/example.com/v1/namespaces/default/widgets/widget",
+		UID:                        "6d9c7e1c-1a8f-4c0a-9d0e-0f1f3d6e3a6b",
+		ResourceVersion:            "42",
+		Generation:                 3,
+		CreationTimestamp:          created,
+		DeletionTimestamp:          &deleted,
+		DeletionGracePeriodSeconds: &gracePeriod,
+		Labels:                     map[string]string{"app": "widget"},
+		Annotations:                map[string]string{"note": "example"},
+		OwnerReferences: []metav1.OwnerReference{{
+			APIVersion: "example.com/v1",
+			Kind:       "Gadget",
+			Name:       "gadget",
+			UID:        "0f1f3d6e-1a8f-4c0a-9d0e-6d9c7e1c3a6b",
+			Controller: &controller,
+		}},
+		Finalizers:  []string{"example.com/finalizer"},
+		ClusterName: "cluster",
+	}
+
+	converted := ObjectMetaFromMetav1(original)
+	assert.DeepEqual(t, &ObjectMeta{
+		Name:                       "widget",
+		GenerateName:               "widget-",
+		Namespace:                  "default",
+		SelfLink:                   "/apis/example.com/v1/namespaces/default/widgets/widget",
+		Uid:                        "6d9c7e1c-1a8f-4c0a-9d0e-0f1f3d6e3a6b",
+		ResourceVersion:            "42",
+		Generation:                 3,
+		CreationTimestamp:          &Time{Seconds: created.Unix(), Nanos: 123456789},
+		DeletionTimestamp:          &Time{Seconds: deleted.Unix()},
+		DeletionGracePeriodSeconds: proto.Int64(30),
+		Labels:                     map[string]string{"app": "widget"},
+		Annotations:                map[string]string{"note": "example"},
+		OwnerReferences: []*OwnerReference{{
+			ApiVersion: "example.com/v1",
+			Kind:       "Gadget",
+			Name:       "gadget",
+			Uid:        "0f1f3d6e-1a8f-4c0a-9d0e-6d9c7e1c3a6b",
+			Controller: proto.Bool(true),
+		}},
+		Finalizers:  []string{"example.com/finalizer"},
+		ClusterName: "cluster",
+	}, converted, protocmp.Transform())
+
+	assert.DeepEqual(t, original, ObjectMetaToMetav1(converted))
+
+	// converted objects don't share memory
+	original.Labels["app"] = "changed"
+	*original.DeletionGracePeriodSeconds = 0
+	original.Finalizers[0] = "changed"
+	assert.Equal(t, "widget", converted.Labels["app"])
+	assert.Equal(t, int64(30), *converted.DeletionGracePeriodSeconds)
+	assert.Equal(t, "example.com/finalizer", converted.Finalizers[0])
+}
+
+func TestObjectMetaConversionEmpty(t *testing.T) {
+	assert.DeepEqual(t, metav1.ObjectMeta{}, ObjectMetaToMetav1(nil))
+	assert.DeepEqual(t, metav1.ObjectMeta{}, ObjectMetaToMetav1(ObjectMetaFromMetav1(metav1.ObjectMeta{})))
+	assert.DeepEqual(t, &ObjectMeta{}, ObjectMetaFromMetav1(metav1.ObjectMeta{}), protocmp.Transform())
+}
+
+func TestListMetaConversion(t *testing.T) {
+	original := metav1.ListMeta{
+		SelfLink:           "/apis/example.com/v1/widgets",
+		ResourceVersion:    "42",
+		Continue:           "token",
+		RemainingItemCount: proto.Int64(10),
+	}
+
+	converted := ListMetaFromMetav1(original)
+	assert.DeepEqual(t, &ListMeta{
+		SelfLink:           "/apis/example.com/v1/widgets",
+		ResourceVersion:    "42",
+		Continue:           "token",
+		RemainingItemCount: proto.Int64(10),
+	}, converted, protocmp.Transform())
+	assert.DeepEqual(t, original, ListMetaToMetav1(converted))
+	assert.DeepEqual(t, metav1.ListMeta{}, ListMetaToMetav1(nil))
+}
+
+func TestConditionsConversion(t *testing.T) {
+	original := []metav1.Condition{{
+		Type:               "Ready",
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: 3,
+		LastTransitionTime: metav1.NewTime(time.Date(2021, 11, 1, 10, 0, 0, 0, time.Local)),
+		Reason:             "Reconciled",
+		Message:            "widget is ready",
+	}}
+
+	converted := ConditionsFromMetav1(original)
+	assert.DeepEqual(t, []*Condition{{
+		Type:               "Ready",
+		Status:             "True",
+		ObservedGeneration: 3,
+		LastTransitionTime: &Time{Seconds: original[0].LastTransitionTime.Unix()},
+		Reason:             "Reconciled",
+		Message:            "widget is ready",
+	}}, converted, protocmp.Transform())
+	assert.DeepEqual(t, original, ConditionsToMetav1(converted))
+
+	assert.Assert(t, ConditionsToMetav1(nil) == nil)
+	assert.Assert(t, ConditionsFromMetav1(nil) == nil)
+}
+
+func TestTimeConversion(t *testing.T) {
+	assert.Assert(t, TimeFromMetav1(metav1.Time{}) == nil)
+	assert.Assert(t, TimeFromMetav1Ptr(nil) == nil)
+	assert.Assert(t, TimeToMetav1(nil).Time.IsZero())
+	assert.Assert(t, TimeToMetav1Ptr(nil) == nil)
+
+	now := metav1.NewTime(time.Unix(1635760800, 42))
+	assert.DeepEqual(t, now, TimeToMetav1(TimeFromMetav1(now)))
+	assert.DeepEqual(t, &now, TimeToMetav1Ptr(TimeFromMetav1Ptr(&now)))
+}
+
+func TestObjectMetaDeepCopy(t *testing.T) {
+	original := ObjectMetaFromMetav1(metav1.ObjectMeta{
+		Name:              "widget",
+		CreationTimestamp: metav1.NewTime(time.Unix(1635760800, 0)),
+		Labels:            map[string]string{"app": "widget"},
+		OwnerReferences:   []metav1.OwnerReference{{Name: "gadget"}},
+	})
+
+	doppelganger := original.DeepCopy()
+	assert.DeepEqual(t, original, doppelganger, protocmp.Transform())
+
+	original.CreationTimestamp.Seconds = 0
+	original.Labels["app"] = "changed"
+	original.OwnerReferences[0].Name = "changed"
+	assert.Equal(t, int64(1635760800), doppelganger.CreationTimestamp.Seconds)
+	assert.Equal(t, "widget", doppelganger.Labels["app"])
+	assert.Equal(t, "gadget", doppelganger.OwnerReferences[0].Name)
+}
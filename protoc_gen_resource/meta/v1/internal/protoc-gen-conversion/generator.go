@@ -0,0 +1,338 @@
+package main
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"github.com/dgodyna/protoc-gen-resource/pkg/templates"
+	"go/format"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+//go:embed conversion.gotmpl
+var conversionTmpl string
+
+// conversionFileSuffix suffix of generated file with conversions.
+const conversionFileSuffix = ".conversion.pb.go"
+
+// metav1Package holds import path of kubernetes meta package, it's imported as metav1.
+const metav1Package = "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+// conversion describes message converted to and from apimachinery type.
+type conversion struct {
+	// Message is name of protobuf message.
+	Message string
+	// Target is apimachinery type of message.
+	Target reflect.Type
+	// Empty describes value nil message is converted to, e.g. "metadata".
+	Empty string
+	// List enables conversions of lists of message.
+	List bool
+}
+
+// conversions are all the messages converted to and from apimachinery types in order of generation.
+var conversions = []conversion{
+	{Message: "ObjectMeta", Target: reflect.TypeOf(metav1.ObjectMeta{}), Empty: "metadata"},
+	{Message: "ListMeta", Target: reflect.TypeOf(metav1.ListMeta{}), Empty: "metadata"},
+	{Message: "OwnerReference", Target: reflect.TypeOf(metav1.OwnerReference{}), Empty: "reference", List: true},
+	{Message: "Condition", Target: reflect.TypeOf(metav1.Condition{}), Empty: "condition", List: true},
+}
+
+// handWritten are messages which conversions are written by hand, as they don't match apimachinery types field by field.
+var handWritten = map[string]bool{"Time": true}
+
+// convertedField is field of message rendered by conversion template.
+type convertedField struct {
+	// Name is go name of field of message.
+	Name string
+	// Target is go name of field of apimachinery type.
+	Target string
+	// To converts field of message to field of apimachinery type.
+	To string
+	// From converts field of apimachinery type to field of message.
+	From string
+}
+
+// convertedMessage is message rendered by conversion template.
+type convertedMessage struct {
+	Name   string
+	Target string
+	Empty  string
+	List   bool
+	Fields []convertedField
+	// Unconverted notes fields of apimachinery type without counterpart in message.
+	Unconverted string
+}
+
+// copyHelper is function rendered by conversion template copying value of field, so converted objects don't share memory.
+type copyHelper struct {
+	Name string
+	// Kind is one of "pointer", "slice" or "map".
+	Kind string
+	// Type is go type of value, element of slice or value of map with string keys.
+	Type string
+}
+
+// generator holds state of generated conversions file.
+type generator struct {
+	imports map[string]string
+	helpers []copyHelper
+}
+
+// generateConversions generates conversions of all the messages of file listed in conversions.
+func generateConversions(gen *protogen.Plugin, fileName string) error {
+	file, ok := gen.FilesByPath[fileName]
+	if !ok {
+		return fmt.Errorf("unable to find file %s", fileName)
+	}
+
+	messages := make(map[string]*protogen.Message, len(file.Messages))
+	for _, m := range file.Messages {
+		if _, ok := findConversion(m.GoIdent.GoName); !ok && !handWritten[m.GoIdent.GoName] {
+			return fmt.Errorf("message '%s' has neither generated nor hand written conversion", m.Desc.FullName())
+		}
+		messages[m.GoIdent.GoName] = m
+	}
+
+	g := &generator{imports: map[string]string{metav1Package: "metav1"}}
+	var converted []convertedMessage
+	for _, c := range conversions {
+		m, ok := messages[c.Message]
+		if !ok {
+			return fmt.Errorf("message '%s' is not found in file %s", c.Message, fileName)
+		}
+		cm, err := g.convertMessage(m, c)
+		if err != nil {
+			return err
+		}
+		converted = append(converted, cm)
+	}
+
+	var imports []string
+	for importPath, alias := range g.imports {
+		if alias == path.Base(importPath) {
+			imports = append(imports, fmt.Sprintf("%q", importPath))
+			continue
+		}
+		imports = append(imports, fmt.Sprintf("%s %q", alias, importPath))
+	}
+	sort.Strings(imports)
+
+	sw := templates.NewSnippetWriter(bytes.NewBuffer([]byte{}), "{{", "}}", nil)
+	sw.Do(conversionTmpl, templates.Args{
+		"package":  string(file.GoPackageName),
+		"imports":  imports,
+		"messages": converted,
+		"helpers":  g.helpers,
+	})
+	if sw.Error() != nil {
+		return fmt.Errorf("unable to generate conversions for file %s : %w", fileName, sw.Error())
+	}
+
+	formattedSources, err := format.Source([]byte(fmt.Sprintf("%v", sw.Out())))
+	if err != nil {
+		return fmt.Errorf("unable to format generated sources : %w", err)
+	}
+
+	_, err = gen.NewGeneratedFile(file.GeneratedFilenamePrefix+conversionFileSuffix, file.GoImportPath).Write(formattedSources)
+
+	return err
+}
+
+// findConversion returns conversion of message with provided go name.
+func findConversion(message string) (conversion, bool) {
+	for _, c := range conversions {
+		if c.Message == message {
+			return c, true
+		}
+	}
+	return conversion{}, false
+}
+
+// convertMessage matches fields of message with fields of apimachinery type by JSON name.
+// Every field of message must have counterpart, fields of apimachinery type without counterpart are not converted.
+func (g *generator) convertMessage(m *protogen.Message, c conversion) (convertedMessage, error) {
+	targets := make(map[string]reflect.StructField, c.Target.NumField())
+	for i := 0; i < c.Target.NumField(); i++ {
+		f := c.Target.Field(i)
+		jsonName := strings.Split(f.Tag.Get("json"), ",")[0]
+		if f.Anonymous || jsonName == "" || jsonName == "-" {
+			continue
+		}
+		targets[jsonName] = f
+	}
+
+	cm := convertedMessage{
+		Name:   m.GoIdent.GoName,
+		Target: g.qualify(c.Target),
+		Empty:  c.Empty,
+		List:   c.List,
+	}
+	matched := make(map[string]bool, len(m.Fields))
+	for _, field := range m.Fields {
+		target, ok := targets[field.Desc.JSONName()]
+		if !ok {
+			return convertedMessage{}, fmt.Errorf("field '%s' of message '%s' has no counterpart in %s",
+				field.Desc.Name(), m.Desc.FullName(), c.Target)
+		}
+		matched[target.Name] = true
+
+		to, from, err := g.convertField(field, target)
+		if err != nil {
+			return convertedMessage{}, fmt.Errorf("field '%s' of message '%s' can't be converted to %s.%s : %w",
+				field.Desc.Name(), m.Desc.FullName(), c.Target, target.Name, err)
+		}
+		cm.Fields = append(cm.Fields, convertedField{Name: field.GoName, Target: target.Name, To: to, From: from})
+	}
+
+	var unconverted []string
+	for i := 0; i < c.Target.NumField(); i++ {
+		if f := c.Target.Field(i); !f.Anonymous && !matched[f.Name] {
+			unconverted = append(unconverted, f.Name)
+		}
+	}
+	switch len(unconverted) {
+	case 0:
+	case 1:
+		cm.Unconverted = fmt.Sprintf("Field %s of %s is not converted.", unconverted[0], cm.Target)
+	default:
+		cm.Unconverted = fmt.Sprintf("Fields %s of %s are not converted.", strings.Join(unconverted, ", "), cm.Target)
+	}
+
+	return cm, nil
+}
+
+// convertField returns go expressions converting field of message to field of apimachinery type and back.
+func (g *generator) convertField(field *protogen.Field, targetField reflect.StructField) (string, string, error) {
+	target := targetField.Type
+	// call returns expressions calling conversion functions with field of input
+	call := func(to, from string) (string, string, error) {
+		return to + "(in." + field.GoName + ")", from + "(in." + targetField.Name + ")", nil
+	}
+
+	switch {
+	case field.Desc.IsMap():
+		key, value := field.Message.Fields[0], field.Message.Fields[1]
+		if key.Desc.Kind() != protoreflect.StringKind || target.Kind() != reflect.Map || target.Key().Kind() != reflect.String {
+			return "", "", fmt.Errorf("only maps with string keys are supported")
+		}
+		goType, err := scalarType(value, target.Elem())
+		if err != nil {
+			return "", "", err
+		}
+		helper := g.helper("copy"+exported(goType)+"Map", "map", goType)
+		return call(helper, helper)
+	case field.Desc.IsList() && field.Message != nil:
+		if target.Kind() != reflect.Slice {
+			return "", "", fmt.Errorf("repeated field must be converted to slice")
+		}
+		if err := g.checkMessage(field.Message, target.Elem()); err != nil {
+			return "", "", err
+		}
+		if c, ok := findConversion(field.Message.GoIdent.GoName); !ok || !c.List {
+			return "", "", fmt.Errorf("conversions of list of '%s' are not generated", field.Message.Desc.FullName())
+		}
+		name := field.Message.GoIdent.GoName + "s"
+		return call(name+"ToMetav1", name+"FromMetav1")
+	case field.Desc.IsList():
+		if target.Kind() != reflect.Slice {
+			return "", "", fmt.Errorf("repeated field must be converted to slice")
+		}
+		goType, err := scalarType(field, target.Elem())
+		if err != nil {
+			return "", "", err
+		}
+		helper := g.helper("copy"+exported(goType)+"s", "slice", goType)
+		return call(helper, helper)
+	case field.Message != nil:
+		name := field.Message.GoIdent.GoName
+		suffix := ""
+		if target.Kind() == reflect.Ptr {
+			target, suffix = target.Elem(), "Ptr"
+		}
+		if err := g.checkMessage(field.Message, target); err != nil {
+			return "", "", err
+		}
+		return call(name+"ToMetav1"+suffix, name+"FromMetav1"+suffix)
+	case field.Desc.HasPresence():
+		if target.Kind() != reflect.Ptr {
+			return "", "", fmt.Errorf("optional field must be converted to pointer")
+		}
+		goType, err := scalarType(field, target.Elem())
+		if err != nil {
+			return "", "", err
+		}
+		helper := g.helper("copy"+exported(goType), "pointer", goType)
+		return call(helper, helper)
+	}
+
+	goType, err := scalarType(field, target)
+	if err != nil {
+		return "", "", err
+	}
+	if target.PkgPath() == "" {
+		return "in." + field.GoName, "in." + targetField.Name, nil
+	}
+	// named type of apimachinery, e.g. types.UID
+	return call(g.qualify(target), goType)
+}
+
+// checkMessage checks that message field is converted to apimachinery type of the same name.
+func (g *generator) checkMessage(message *protogen.Message, target reflect.Type) error {
+	if target.Kind() != reflect.Struct || target.Name() != message.GoIdent.GoName {
+		return fmt.Errorf("message '%s' can't be converted to %s", message.Desc.FullName(), target)
+	}
+	return nil
+}
+
+// scalarType returns go type of scalar field, if it's the same kind as apimachinery type.
+func scalarType(field *protogen.Field, target reflect.Type) (string, error) {
+	goTypes := map[protoreflect.Kind]reflect.Kind{
+		protoreflect.BoolKind:   reflect.Bool,
+		protoreflect.StringKind: reflect.String,
+		protoreflect.Int32Kind:  reflect.Int32, protoreflect.Sint32Kind: reflect.Int32, protoreflect.Sfixed32Kind: reflect.Int32,
+		protoreflect.Int64Kind: reflect.Int64, protoreflect.Sint64Kind: reflect.Int64, protoreflect.Sfixed64Kind: reflect.Int64,
+		protoreflect.Uint32Kind: reflect.Uint32, protoreflect.Fixed32Kind: reflect.Uint32,
+		protoreflect.Uint64Kind: reflect.Uint64, protoreflect.Fixed64Kind: reflect.Uint64,
+		protoreflect.FloatKind:  reflect.Float32,
+		protoreflect.DoubleKind: reflect.Float64,
+	}
+
+	kind, ok := goTypes[field.Desc.Kind()]
+	if !ok || kind != target.Kind() {
+		return "", fmt.Errorf("%s field can't be converted to %s", field.Desc.Kind(), target)
+	}
+	return kind.String(), nil
+}
+
+// exported returns name with the first letter in upper case, e.g. "Int64" for "int64".
+func exported(name string) string {
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// helper registers copy helper and returns its name.
+func (g *generator) helper(name, kind, goType string) string {
+	for _, h := range g.helpers {
+		if h.Name == name {
+			return name
+		}
+	}
+	g.helpers = append(g.helpers, copyHelper{Name: name, Kind: kind, Type: goType})
+	return name
+}
+
+// qualify returns qualified name of named go type and imports its package.
+func (g *generator) qualify(t reflect.Type) string {
+	alias, ok := g.imports[t.PkgPath()]
+	if !ok {
+		alias = path.Base(t.PkgPath())
+		g.imports[t.PkgPath()] = alias
+	}
+	return alias + "." + t.Name()
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"github.com/dgodyna/protoc-gen-resource/pkg/protoc"
+	v1 "github.com/dgodyna/protoc-gen-resource/protoc_gen_resource/meta/v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/pluginpb"
+	"gotest.tools/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestGenerateConversions checks that checked in conversions are in sync with meta.proto.
+func TestGenerateConversions(t *testing.T) {
+	file := v1.File_protoc_gen_resource_meta_v1_meta_proto
+	req := &pluginpb.CodeGeneratorRequest{
+		FileToGenerate: []string{file.Path()},
+		Parameter:      proto.String("module=github.com/dgodyna/protoc-gen-resource"),
+		ProtoFile:      []*descriptorpb.FileDescriptorProto{protodesc.ToFileDescriptorProto(file)},
+	}
+
+	resp := protoc.ApplyPluginFunction(generateConversions, nil, req)
+	assert.Equal(t, "", resp.GetError())
+	assert.Equal(t, 1, len(resp.File))
+	assert.Equal(t, "protoc_gen_resource/meta/v1/meta.conversion.pb.go", resp.File[0].GetName())
+
+	want, err := os.ReadFile(filepath.Join("..", "..", "meta.conversion.pb.go"))
+	assert.NilError(t, err)
+	assert.Equal(t, string(want), resp.File[0].GetContent(), "meta.conversion.pb.go is outdated, run go generate")
+}